@@ -0,0 +1,413 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+const addUsage = `Usage:
+  goarm add <component> [flags]
+
+Components:
+  db       wire an additional database into an existing project
+  cache    wire a redis or in-memory cache into Repo
+  openapi  serve an OpenAPI document of the routes at /openapi.json and /docs
+  grpc     add a gRPC server with a sample proto service and buf codegen
+  sqlc     generate the queries of Repo with sqlc
+  jwt      add JWT auth with an Auth middleware and login/refresh handlers
+  metrics  serve Prometheus metrics at /metrics and pprof on an admin port
+`
+
+// addCommand handles `goarm add`, which extends an existing project.
+func addCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, addUsage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "db":
+		return addDatabaseCommand(args[1:])
+	case "cache":
+		return addCacheCommand(args[1:])
+	case "openapi":
+		return addOpenAPICommand(args[1:])
+	case "grpc":
+		return addGRPCCommand(args[1:])
+	case "sqlc":
+		return addSQLCCommand(args[1:])
+	case "jwt":
+		return addJWTCommand(args[1:])
+	case "metrics":
+		return addMetricsCommand(args[1:])
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, addUsage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown component %q\n\n%s", args[0], addUsage)
+		return exitUsage
+	}
+}
+
+// addDatabaseCommand handles `goarm add db`. It runs the same steps as
+// project generation against an existing project and can be repeated safely.
+func addDatabaseCommand(args []string) int {
+	flags := flag.NewFlagSet("add db", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	database := flags.String("db", "", "database ("+databaseNames()+")")
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add db <database> [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if len(positional) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional[1:], " "))
+		return exitUsage
+	}
+	if *database == "" && len(positional) == 1 {
+		*database = positional[0]
+	}
+	if *database == "" {
+		fmt.Fprintln(os.Stderr, "Error: missing required flags: --db")
+		return exitUsage
+	}
+
+	dbType, err := domain.ParseDbType(*database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := bindDatabase(p, app, dbType); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding %s: %v\n", dbType, err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ %s added to %s.\n", dbType, app.Module)
+	return exitOK
+}
+
+// addCacheCommand handles `goarm add cache`.
+func addCacheCommand(args []string) int {
+	flags := flag.NewFlagSet("add cache", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	cache := flags.String("cache", "", "cache ("+cacheNames()+")")
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add cache <cache> [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if len(positional) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional[1:], " "))
+		return exitUsage
+	}
+	if *cache == "" && len(positional) == 1 {
+		*cache = positional[0]
+	}
+	if *cache == "" {
+		fmt.Fprintln(os.Stderr, "Error: missing required flags: --cache")
+		return exitUsage
+	}
+
+	cacheType, err := domain.ParseCacheType(*cache)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := bindCache(p, app.Module, app.Envs, cacheType); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding %s: %v\n", cacheType, err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ %s cache added to %s.\n", cacheType, app.Module)
+	return exitOK
+}
+
+// addOpenAPICommand handles `goarm add openapi`.
+func addOpenAPICommand(args []string) int {
+	flags := flag.NewFlagSet("add openapi", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add openapi [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional, " "))
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if app.Framework == "" {
+		fmt.Fprintln(os.Stderr, "Error: can't detect the web framework of the project from its go.mod")
+		return exitFailure
+	}
+
+	if err := bindOpenAPI(p, app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding openapi: %v\n", err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ OpenAPI document added to %s, served at %s and %s.\n", app.Module, docsSpecURL, docsUIURL)
+	return exitOK
+}
+
+// addGRPCCommand handles `goarm add grpc`.
+func addGRPCCommand(args []string) int {
+	flags := flag.NewFlagSet("add grpc", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add grpc [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional, " "))
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := bindGRPC(p, app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding grpc: %v\n", err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ gRPC server added to %s, listening on port %s.\n", app.Module, grpcPort)
+	return exitOK
+}
+
+// addSQLCCommand handles `goarm add sqlc`, which targets the first database
+// of the project.
+func addSQLCCommand(args []string) int {
+	flags := flag.NewFlagSet("add sqlc", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add sqlc [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional, " "))
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	dbType, err := primaryDatabase(p)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := bindSQLC(p, app, dbType); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding sqlc: %v\n", err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ sqlc added to %s, run 'make sqlc' after changing queries/ or migrations/.\n", app.Module)
+	return exitOK
+}
+
+// addJWTCommand handles `goarm add jwt`.
+func addJWTCommand(args []string) int {
+	flags := flag.NewFlagSet("add jwt", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add jwt [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional, " "))
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if app.Framework == "" {
+		fmt.Fprintln(os.Stderr, "Error: can't detect the web framework of the project from its go.mod")
+		return exitFailure
+	}
+
+	if err := bindJWT(p, app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding jwt: %v\n", err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ JWT auth added to %s, routes registered under %s.\n", app.Module, authRoutesPrefix)
+	return exitOK
+}
+
+// addMetricsCommand handles `goarm add metrics`.
+func addMetricsCommand(args []string) int {
+	flags := flag.NewFlagSet("add metrics", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm add metrics [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional, " "))
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if app.Framework == "" {
+		fmt.Fprintln(os.Stderr, "Error: can't detect the web framework of the project from its go.mod")
+		return exitFailure
+	}
+
+	if err := bindMetrics(p, app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error binding metrics: %v\n", err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ Metrics added to %s, served at %s.\n", app.Module, metricsPath)
+	return exitOK
+}
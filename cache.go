@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/manager"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Names used when a cache is wired into a project.
+const (
+	cachePackage = "cache"
+	cacheField   = "cache"
+	redisClient  = "redisClient"
+)
+
+// bindCache wires cacheType into the project: it copies pkg/cache with the
+// Cache interface, adds a cache field to Repo and passes the cache to
+// repo.NewRepo. Redis also gets its client package, a redis section in every
+// env file and a docker-compose service. Steps already applied are skipped; a
+// project holds a single cache.
+func bindCache(p *project, module string, envs []string, cacheType domain.CacheType) error {
+	if cacheType == domain.CacheTypeNone {
+		return nil
+	}
+
+	fields, err := utils.StructFields(p.path(repoFile), "Repo")
+	if err != nil {
+		return fmt.Errorf("failed to read Repo struct: %w", err)
+	}
+
+	cacheValue := cachePackage + ".NewMemory()"
+	if cacheType == domain.CacheTypeRedis {
+		cacheValue = fmt.Sprintf("%s.NewRedis(%s)", cachePackage, redisClient)
+	}
+	if _, wired := fields[cacheField]; wired {
+		body, err := os.ReadFile(p.path(appRunFile))
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(body), cacheValue) {
+			return fmt.Errorf("the project already has a cache, %s can't be added next to it", cacheType)
+		}
+	}
+
+	// ───── Step 1: Write pkg/cache ─────
+	for name, content := range manager.ManagePackage(cachePackage).GetFiles() {
+		if name == "redis.go" && cacheType != domain.CacheTypeRedis {
+			continue
+		}
+
+		rel := path.Join("pkg", cachePackage, name)
+		if p.exists(rel) {
+			continue
+		}
+		if err := p.writeFile(rel, content); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", rel, err)
+		}
+	}
+
+	// ───── Step 2: Wire the Redis client ─────
+	if cacheType == domain.CacheTypeRedis {
+		if err := bindRedis(p, module, envs); err != nil {
+			return err
+		}
+	}
+
+	// ───── Step 3: Update Repo struct and NewRepo ─────
+	cachePath := path.Join(module, "pkg", cachePackage)
+	if err := p.addImport(repoFile, cachePath); err != nil {
+		return fmt.Errorf("failed to add cache import to repo/build.go: %w", err)
+	}
+	if err := p.appendFieldStruct(repoFile, "Repo", cacheField+" cache.Cache"); err != nil {
+		return fmt.Errorf("failed to append field to Repo struct: %w", err)
+	}
+	if err := p.appendFuncArgument(repoFile, "NewRepo", cacheField, "cache.Cache"); err != nil {
+		return fmt.Errorf("failed to append argument to NewRepo function: %w", err)
+	}
+	if err := p.addReturnField(repoFile, "NewRepo", cacheField); err != nil {
+		return fmt.Errorf("failed to set constructor return value: %w", err)
+	}
+
+	// ───── Step 4: Update app run layer ─────
+	if err := p.addImport(appRunFile, cachePath); err != nil {
+		return fmt.Errorf("failed to add cache import to app/build.go: %w", err)
+	}
+	if err := p.addCallArgument(appRunFile, "repo.NewRepo", cacheValue); err != nil {
+		return fmt.Errorf("failed to inject cache into repo.NewRepo: %w", err)
+	}
+
+	return nil
+}
+
+// bindRedis copies pkg/redis, appends its config to every env file, adds it
+// to AppConfigs and adds the redis service to docker-compose.yaml.
+func bindRedis(p *project, module string, envs []string) error {
+	const name = "redis"
+	files := manager.ManageCache(name)
+
+	rel := path.Join("pkg", name, "init.go")
+	if !p.exists(rel) {
+		if err := writeClientFile(p, module, rel, files.GetInit()); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", rel, err)
+		}
+	}
+
+	configKey := regexp.MustCompile(`(?m)^` + name + `:`)
+	for _, env := range envs {
+		configPath := path.Join("etc", env+".yaml")
+
+		body, err := os.ReadFile(p.path(configPath))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", configPath, err)
+		}
+		if configKey.Match(body) {
+			continue
+		}
+
+		if err := p.appendToFile(configPath, files.GetConfig()); err != nil {
+			return fmt.Errorf("failed to append config to %q: %w", configPath, err)
+		}
+	}
+
+	appField := fmt.Sprintf("Redis %s.Config `mapstructure:\"%s\" yaml:\"%s\"`", name, name, name)
+	if err := p.appendFieldStruct(appStructFile, "AppConfigs", appField); err != nil {
+		return fmt.Errorf("failed to append field to AppConfigs struct: %w", err)
+	}
+	pkgPath := path.Join(module, "pkg", name)
+	for _, file := range []string{appStructFile, appRunFile} {
+		if err := p.addImport(file, pkgPath); err != nil {
+			return fmt.Errorf("failed to add import to %s: %w", file, err)
+		}
+	}
+	connect := "redis.NewClient(ctx, appConfig.Redis)"
+	closeClient := "defer " + redisClient + ".Close()"
+	check := fmt.Sprintf("func(ctx context.Context) error {\n\treturn %s.Ping(ctx).Err()\n}", redisClient)
+	if err := bindClient(p, redisClient, connect, closeClient, check, name); err != nil {
+		return err
+	}
+
+	if !p.exists(dockerComposeFile) {
+		return nil
+	}
+	if err := bindCacheService(p, domain.CacheTypeRedis); err != nil {
+		return fmt.Errorf("failed to write to docker-compose file: %w", err)
+	}
+
+	return nil
+}
+
+// bindCacheService adds the container of cacheType to docker-compose.yaml and
+// makes the app depend on it.
+func bindCacheService(p *project, cacheType domain.CacheType) error {
+	body, err := os.ReadFile(p.path(dockerComposeFile))
+	if err != nil {
+		return err
+	}
+	compose := string(body)
+
+	service := cacheType.ShortName()
+	if regexp.MustCompile(`(?m)^  ` + service + `:`).MatchString(compose) {
+		return nil
+	}
+
+	anchor := "\nvolumes:"
+	if !strings.Contains(compose, anchor) {
+		anchor = "\nnetworks:"
+	}
+	if !strings.Contains(compose, anchor) {
+		return fmt.Errorf("can't find where to add the %s service", service)
+	}
+	if err := p.replaceInFile(dockerComposeFile, anchor, cacheType.GetDockerConfig()+anchor); err != nil {
+		return err
+	}
+
+	hostEnv := fmt.Sprintf("      - %s_HOST=%s\n", strings.ToUpper(service), service)
+	if strings.Contains(compose, "    environment:\n") {
+		if err := p.replaceInFile(dockerComposeFile, "    environment:\n", "    environment:\n"+hostEnv); err != nil {
+			return err
+		}
+	}
+
+	if strings.Contains(compose, "    depends_on:\n") {
+		return p.replaceInFile(dockerComposeFile, "    depends_on:\n", "    depends_on:\n      - "+service+"\n")
+	}
+	return p.replaceInFile(dockerComposeFile, "    ports:\n", "    depends_on:\n      - "+service+"\n    ports:\n")
+}
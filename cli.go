@@ -0,0 +1,307 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Exit codes returned by run.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+const usage = `Usage:
+  goarm [command] [flags]
+
+Commands:
+  new       create a new project (default when no command is given)
+  add       add a component to an existing project
+  generate  generate code in an existing project
+  export    describe the API of an existing project
+  help      show this help
+
+Run "goarm <command> -h" for the flags of a command.
+`
+
+// run dispatches the command line to a sub command and returns the exit code.
+func run(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return newCommand(args)
+	}
+
+	switch args[0] {
+	case "new":
+		return newCommand(args[1:])
+	case "add":
+		return addCommand(args[1:])
+	case "generate":
+		return generateCommand(args[1:])
+	case "export":
+		return exportCommand(args[1:])
+	case "help":
+		fmt.Fprint(os.Stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+}
+
+// newCommand handles `goarm new`. Values passed as flags are validated and
+// used as is; the interactive form only asks for the missing ones and only
+// when stdin is a terminal.
+func newCommand(args []string) int {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	specFile := flags.String("f", "", "path to a goarm.yaml spec describing the project")
+	module := flags.String("module", "", "Go module path, e.g. github.com/acme/billing")
+	name := flags.String("name", "", "output directory (default: last element of the module path)")
+	framework := flags.String("framework", "", "web framework ("+frameworkNames()+")")
+	database := flags.String("db", "", "database ("+databaseNames()+")")
+	orm := flags.String("orm", "", "data access of SQL databases ("+dataAccessNames()+")")
+	cache := flags.String("cache", "", "cache ("+cacheNames()+")")
+	features := flags.String("features", "", "comma separated features ("+featureNames()+")")
+	force := flags.Bool("force", false, "overwrite the project directory if it is not empty")
+	dryRun := flags.Bool("dry-run", false, "print the files, edits and diffs that would be generated without writing anything")
+	asJSON := flags.Bool("json", false, "print the --dry-run report as JSON")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm new [module] [flags]\n  goarm new -f goarm.yaml\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if len(positional) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(positional[1:], " "))
+		return exitUsage
+	}
+	if *module == "" && len(positional) == 1 {
+		*module = positional[0]
+	}
+
+	var app domain.App
+	if *specFile != "" {
+		if app, err = utils.LoadSpec(*specFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid spec:\n%v\n", err)
+			return exitUsage
+		}
+	}
+
+	if app, err = appFromFlags(app, *module, *name, *framework, *database, *orm, *cache, *features); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	if missing := missingValues(app); len(missing) > 0 {
+		if !isTerminal(os.Stdin) {
+			fmt.Fprintf(os.Stderr, "Error: missing required flags: %s\n", strings.Join(missing, ", "))
+			return exitUsage
+		}
+
+		if app, err = utils.OpenForm(app); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		// The form may have picked the data access
+		if err := app.CheckFeatures(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	if *asJSON && !*dryRun {
+		fmt.Fprintln(os.Stderr, "Error: --json can only be used with --dry-run")
+		return exitUsage
+	}
+
+	opts := generateOptions{force: *force, dryRun: *dryRun, json: *asJSON}
+	if err := generateProject(app, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if opts.dryRun {
+		return exitOK
+	}
+
+	fmt.Println("✅ Project setup completed successfully.")
+	return exitOK
+}
+
+// parseFlags parses args and returns the positional arguments. Positional
+// arguments may appear before the flags, e.g. `goarm new svc --db sqlite`.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// appFromFlags validates the given flag values and sets them on app, so flags
+// take precedence over a spec file. Empty values leave app unchanged.
+//
+// A bare --name without --module is used as both, as in `goarm new --name svc`.
+func appFromFlags(app domain.App, module, name, framework, database, orm, cache, features string) (domain.App, error) {
+	if module == "" && app.Module == "" {
+		module = name
+	}
+
+	if module != "" {
+		if err := utils.ValidateModulePath(module); err != nil {
+			return app, err
+		}
+		app.Module = module
+		app.Name = utils.ProjectDir(module)
+	}
+
+	if name != "" {
+		if err := utils.ValidateProjectName(name); err != nil {
+			return app, err
+		}
+		app.Name = name
+	}
+
+	if framework != "" {
+		f, err := domain.ParseFrameworkType(framework)
+		if err != nil {
+			return app, err
+		}
+		app.Framework = f
+	}
+
+	if database != "" {
+		d, err := domain.ParseDbType(database)
+		if err != nil {
+			return app, err
+		}
+		app.DbType = d
+	}
+
+	if orm != "" {
+		a, err := domain.ParseDataAccess(orm)
+		if err != nil {
+			return app, err
+		}
+		app.DataAccess = a
+	}
+
+	if cache != "" {
+		c, err := domain.ParseCacheType(cache)
+		if err != nil {
+			return app, err
+		}
+		app.Cache = c
+	}
+
+	if features != "" {
+		app.Features = []domain.Feature{}
+		for _, value := range strings.Split(features, ",") {
+			f, err := domain.ParseFeature(strings.TrimSpace(value))
+			if err != nil {
+				return app, err
+			}
+			if !app.HasFeature(f) {
+				app.Features = append(app.Features, f)
+			}
+		}
+	}
+
+	if err := app.CheckFeatures(); err != nil {
+		return app, err
+	}
+
+	return app, nil
+}
+
+// missingValues returns the flags of the required values that are not set.
+func missingValues(app domain.App) []string {
+	var missing []string
+	if app.Module == "" {
+		missing = append(missing, "--module")
+	}
+	if app.Framework == "" {
+		missing = append(missing, "--framework")
+	}
+	if app.DbType == "" {
+		missing = append(missing, "--db")
+	}
+
+	return missing
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+func frameworkNames() string {
+	names := make([]string, 0, len(domain.SupportedFrameworkTypes))
+	for _, f := range domain.SupportedFrameworkTypes {
+		names = append(names, f.ToDirectory())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func databaseNames() string {
+	names := make([]string, 0, len(domain.SupportedDatabaseTypes))
+	for _, d := range domain.SupportedDatabaseTypes {
+		names = append(names, d.ShortName())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func dataAccessNames() string {
+	names := make([]string, 0, len(domain.SupportedDataAccess))
+	for _, a := range domain.SupportedDataAccess {
+		names = append(names, a.ShortName())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func cacheNames() string {
+	names := make([]string, 0, len(domain.SupportedCacheTypes))
+	for _, c := range domain.SupportedCacheTypes {
+		names = append(names, c.ShortName())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func featureNames() string {
+	names := make([]string, 0, len(domain.SupportedFeatures))
+	for _, f := range domain.SupportedFeatures {
+		names = append(names, string(f))
+	}
+
+	return strings.Join(names, ", ")
+}
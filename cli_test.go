@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+func TestAppFromFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		database string
+		orm      string
+		features string
+		wantErr  string
+	}{
+		{name: "sqlc on postgres", database: "postgres", features: "docker,sqlc"},
+		{name: "sqlc before the database is picked", features: "sqlc"},
+		{name: "sqlc on mongo", database: "mongo", features: "sqlc", wantErr: "sqlc needs an SQL database"},
+		{name: "sqlc with gorm", database: "mysql", orm: "gorm", features: "sqlc", wantErr: "sqlc can't be used with gorm"},
+		{name: "gorm on mongo", database: "mongo", orm: "gorm", features: "docker", wantErr: "gorm needs an SQL database"},
+		{name: "ent on mongo", database: "mongo", orm: "ent", features: "sqlc", wantErr: "ent needs an SQL database"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, err := appFromFlags(domain.App{}, "github.com/acme/shop", "", "gin", tt.database, tt.orm, "", tt.features)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				if !app.HasFeature(domain.FeatureSQLC) {
+					t.Fatalf("unexpected features: %v", app.Features)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/manager"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Project files touched when a database is wired in.
+const (
+	appStructFile     = "internal/domain/app.go"
+	repoFile          = "internal/repo/build.go"
+	appRunFile        = "internal/app/build.go"
+	dockerComposeFile = "docker-compose.yaml"
+)
+
+// bindDatabase wires dbType into the project: it copies the client package,
+// appends its config to every env file, adds it to AppConfigs and Repo and
+// passes the client to repo.NewRepo. The client Repo holds follows
+// app.DataAccess. Every step is skipped when it was already applied, so
+// binding the same database twice is a no-op.
+func bindDatabase(p *project, app domain.App, dbType domain.DbType) error {
+	module, style := app.Module, app.DataAccess
+	if !dbType.IsSQL() {
+		style = domain.DataAccessRaw
+	}
+
+	coreDB := dbType.ToCoreDatabase()
+	dbValue := dbType.RepoVal(style)
+	manager := manager.Manage(coreDB)
+
+	configField, repoField, err := databaseFields(p, dbType)
+	if err != nil {
+		return err
+	}
+
+	baseDir := path.Join("pkg", coreDB)
+
+	// ───── Step 1: Write Go source files ─────
+	files := map[string][]byte{
+		"init.go": manager.Database.GetInit(),
+	}
+	switch style {
+	case domain.DataAccessGORM:
+		files["gorm.go"] = manager.Database.GetGorm()
+	case domain.DataAccessEnt:
+		files["ent.go"] = manager.Database.GetEnt()
+	}
+	for name, content := range files {
+		path := path.Join(baseDir, name)
+		if p.exists(path) {
+			continue
+		}
+		if err := writeClientFile(p, module, path, content); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", path, err)
+		}
+	}
+
+	// ───── Step 2: Append config to env files ─────
+	configKey := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(coreDB) + `:`)
+	for _, env := range app.Envs {
+		configPath := path.Join("etc", env+".yaml")
+
+		body, err := os.ReadFile(p.path(configPath))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", configPath, err)
+		}
+		if configKey.Match(body) {
+			continue
+		}
+
+		if err := p.appendToFile(configPath, manager.Database.GetConfig()); err != nil {
+			return fmt.Errorf("failed to append config to %q: %w", configPath, err)
+		}
+	}
+
+	// ───── Step 3: Add field to AppConfig struct ─────
+	appField := fmt.Sprintf(`%s %s.Config `+"`mapstructure:\"%s\" yaml:\"%s\"`", configField, coreDB, coreDB, coreDB)
+	if err := p.appendFieldStruct(appStructFile, "AppConfigs", appField); err != nil {
+		return fmt.Errorf("failed to append field to AppConfigs struct: %w", err)
+	}
+
+	// ───── Step 4: Add import for DB package ─────
+	if err := p.addImport(appStructFile, path.Join(module, "pkg", coreDB)); err != nil {
+		return fmt.Errorf("failed to add import to app.go: %w", err)
+	}
+
+	// ───── Step 5: Update Repo struct ─────
+	if err := p.addImport(repoFile, dbType.RepoPackage(module, style)); err != nil {
+		return fmt.Errorf("failed to add DB import to repo/build.go: %w", err)
+	}
+
+	if err := p.appendFieldStruct(repoFile, "Repo", fmt.Sprintf("%s *%s", repoField, dbValue)); err != nil {
+		return fmt.Errorf("failed to append field to Repo struct: %w", err)
+	}
+
+	// ───── Step 6: Update NewRepo constructor ─────
+	if err := p.appendFuncArgument(repoFile, "NewRepo", repoField, "*"+dbValue); err != nil {
+		return fmt.Errorf("failed to append argument to NewRepo function: %w", err)
+	}
+	if err := p.addReturnField(repoFile, "NewRepo", repoField); err != nil {
+		return fmt.Errorf("failed to set constructor return value: %w", err)
+	}
+
+	// ───── Step 7: Update app run layer ─────
+	if err := p.addImport(appRunFile, path.Join(module, "pkg", coreDB)); err != nil {
+		return fmt.Errorf("failed to add DB import to app/build.go: %w", err)
+	}
+
+	constructor, callArg := "NewClient", repoField
+	closeClient := fmt.Sprintf("defer %s.Close()", repoField)
+	check := repoField + ".PingContext"
+	switch {
+	case !dbType.IsSQL():
+		closeClient = fmt.Sprintf("defer func() {\n\t_ = %s.Client().Disconnect(context.WithoutCancel(ctx))\n}()", repoField)
+		check = fmt.Sprintf("func(ctx context.Context) error {\n\treturn %s.Client().Ping(ctx, nil)\n}", repoField)
+	case style == domain.DataAccessGORM:
+		constructor = "NewGorm"
+		closeClient = fmt.Sprintf("if sqlDB, err := %s.DB(); err == nil {\n\tdefer sqlDB.Close()\n}", repoField)
+		check = fmt.Sprintf("func(ctx context.Context) error {\n\tsqlDB, err := %s.DB()\n\tif err != nil {\n\t\treturn err\n\t}\n\treturn sqlDB.PingContext(ctx)\n}", repoField)
+	case style == domain.DataAccessEnt:
+		if err := p.addImport(appRunFile, dbType.RepoPackage(module, style)); err != nil {
+			return fmt.Errorf("failed to add ent import to app/build.go: %w", err)
+		}
+		constructor, callArg = "NewEntDriver", fmt.Sprintf("ent.NewClient(ent.Driver(%s))", repoField)
+		check = repoField + ".DB().PingContext"
+	case dbType == domain.DBTypePostgres:
+		check = repoField + ".Ping"
+	}
+	connect := fmt.Sprintf("%s.%s(ctx, appConfig.%s)", coreDB, constructor, configField)
+	if err := bindClient(p, repoField, connect, closeClient, check, dbType.ShortName()); err != nil {
+		return err
+	}
+	if err := bindPoolMetrics(p, app, dbType, repoField); err != nil {
+		return err
+	}
+	if err := p.addCallArgument(appRunFile, "repo.NewRepo", callArg); err != nil {
+		return fmt.Errorf("failed to inject database client into repo.NewRepo: %w", err)
+	}
+
+	// ───── Step 8: Add the database service to docker-compose ─────
+	if !p.exists(dockerComposeFile) {
+		return nil
+	}
+	if err := bindDockerService(p, dbType, configField == primaryConfigField); err != nil {
+		return fmt.Errorf("failed to write to docker-compose file: %w", err)
+	}
+
+	return nil
+}
+
+// bindClient connects to service in app.Run before repo.NewRepo is called:
+// the client returned by connect is assigned to name and a failed connection
+// is returned from app.Run. closeClient defers closing the client, so the
+// clients are closed in reverse order once the server has shut down. check
+// pings the client and is registered as the readiness check of service.
+func bindClient(p *project, name, connect, closeClient, check, service string) error {
+	if err := p.addImport(appRunFile, "fmt"); err != nil {
+		return fmt.Errorf("failed to add import to app/build.go: %w", err)
+	}
+
+	statements := []string{
+		fmt.Sprintf("%s, err := %s", name, connect),
+		fmt.Sprintf("if err != nil {\n\treturn fmt.Errorf(\"can't connect to %s: %%w\", err)\n}", service),
+		closeClient,
+	}
+
+	// Projects generated before the readiness checks don't create them
+	body, err := os.ReadFile(p.path(appRunFile))
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", appRunFile, err)
+	}
+	if strings.Contains(string(body), healthStatement) {
+		statements = append(statements, fmt.Sprintf("health.Add(%q, %s)", service, check))
+	}
+
+	for _, stmt := range statements {
+		if err := p.insertStatement(appRunFile, "Run", "repo := repo.NewRepo(", stmt); err != nil {
+			return fmt.Errorf("failed to connect to %s in app/build.go: %w", service, err)
+		}
+	}
+
+	return nil
+}
+
+// connectPackage is the manager package the clients retry their first
+// connection with, copied into projects as pkg/connect.
+const connectPackage = "github.com/MH-KodaCore/goarm/manager/connect"
+
+// writeClientFile writes the client source content to rel. If the client
+// imports connectPackage, the package is written to pkg/connect and the
+// import points at it.
+func writeClientFile(p *project, module, rel string, content []byte) error {
+	quoted := strconv.Quote(connectPackage)
+	if !bytes.Contains(content, []byte(quoted)) {
+		return p.writeFile(rel, content)
+	}
+
+	name := path.Base(connectPackage)
+	for file, source := range manager.ManagePackage(name).GetFiles() {
+		dst := path.Join("pkg", name, file)
+		if p.exists(dst) {
+			continue
+		}
+		if err := p.writeFile(dst, source); err != nil {
+			return err
+		}
+	}
+
+	imported := strconv.Quote(path.Join(module, "pkg", name))
+	return p.writeFile(rel, bytes.Replace(content, []byte(quoted), []byte(imported), 1))
+}
+
+// healthStatement creates the readiness checks in app.Run.
+const healthStatement = "health := health.New()"
+
+// primaryConfigField is the AppConfigs field of the first database of a project.
+const primaryConfigField = "DB"
+
+// databaseFields returns the AppConfigs and Repo field names for dbType. A
+// database that is already wired keeps its names. Otherwise the first database
+// gets DB/db and every further one <Name>DB/<name>DB, e.g. MysqlDB/mysqlDB.
+func databaseFields(p *project, dbType domain.DbType) (configField, repoField string, err error) {
+	fields, err := utils.StructFields(p.path(appStructFile), "AppConfigs")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read AppConfigs struct: %w", err)
+	}
+
+	configType := dbType.ToCoreDatabase() + ".Config"
+	for name, typ := range fields {
+		if typ == configType {
+			configField = name
+		}
+	}
+
+	if configField == "" {
+		configField = primaryConfigField
+		if _, taken := fields[primaryConfigField]; taken {
+			configField = dbType.ToCoreConfig() + "DB"
+		}
+	}
+
+	if configField == primaryConfigField {
+		return configField, "db", nil
+	}
+
+	runes := []rune(configField)
+	runes[0] = unicode.ToLower(runes[0])
+	return configField, string(runes), nil
+}
+
+// primaryDatabase returns the type of the first database of the project, the
+// one AppConfigs.DB configures.
+func primaryDatabase(p *project) (domain.DbType, error) {
+	fields, err := utils.StructFields(p.path(appStructFile), "AppConfigs")
+	if err != nil {
+		return "", fmt.Errorf("failed to read AppConfigs struct: %w", err)
+	}
+
+	for _, dbType := range domain.SupportedDatabaseTypes {
+		if fields[primaryConfigField] == dbType.ToCoreDatabase()+".Config" {
+			return dbType, nil
+		}
+	}
+
+	return "", errors.New("the project has no database")
+}
+
+// bindDockerService adds the container of dbType to docker-compose.yaml. The
+// first database fills the @db/@dn placeholders of the template as service
+// "db"; further ones are added as a service named after the driver.
+func bindDockerService(p *project, dbType domain.DbType, primary bool) error {
+	body, err := os.ReadFile(p.path(dockerComposeFile))
+	if err != nil {
+		return err
+	}
+	compose := string(body)
+
+	if primary && strings.Contains(compose, "@db") {
+		if err := p.replaceInFile(dockerComposeFile, "@db", dbType.GetDockerConfig()); err != nil {
+			return err
+		}
+		if err := p.replaceInFile(dockerComposeFile, "@dn", dbType.GetDockerDependence()); err != nil {
+			return err
+		}
+		return bindDockerVolume(p, dbType)
+	}
+
+	// SQLite runs inside the app container
+	if dbType.GetDockerDependence() == "" {
+		return nil
+	}
+
+	service := dbType.ToCoreDatabase()
+	config := strings.Replace(dbType.GetDockerConfig(), "\n  db:\n", "\n  "+service+":\n", 1)
+	if strings.Contains(compose, strings.TrimSpace(dbType.GetDockerConfig())) ||
+		strings.Contains(compose, strings.TrimSpace(config)) {
+		return nil
+	}
+
+	anchor := "\nvolumes:"
+	if !strings.Contains(compose, anchor) {
+		anchor = "\nnetworks:"
+	}
+	if !strings.Contains(compose, anchor) {
+		return fmt.Errorf("can't find where to add the %s service", service)
+	}
+	if err := p.replaceInFile(dockerComposeFile, anchor, config+anchor); err != nil {
+		return err
+	}
+
+	if err := bindDockerDependency(p, service); err != nil {
+		return err
+	}
+
+	return bindDockerVolume(p, dbType)
+}
+
+// appServiceBlock matches the app service of docker-compose.yaml with its
+// indented settings.
+var appServiceBlock = regexp.MustCompile(`(?m)^  app:\n(?:    .*\n)*`)
+
+// bindDockerDependency makes the app service of docker-compose.yaml depend on
+// service, adding depends_on when the app has none yet, e.g. with SQLite as
+// the first database.
+func bindDockerDependency(p *project, service string) error {
+	body, err := os.ReadFile(p.path(dockerComposeFile))
+	if err != nil {
+		return err
+	}
+
+	app := appServiceBlock.FindString(string(body))
+	if app == "" {
+		return errors.New("can't find the app service")
+	}
+
+	entry := "      - " + service + "\n"
+	switch {
+	case strings.Contains(app, entry):
+		return nil
+	case strings.Contains(app, "    depends_on:\n"):
+		return p.replaceInFile(dockerComposeFile, app, strings.Replace(app, "    depends_on:\n", "    depends_on:\n"+entry, 1))
+	default:
+		return p.replaceInFile(dockerComposeFile, app, app+"    depends_on:\n"+entry)
+	}
+}
+
+// bindDockerVolume declares the named volume the service of dbType stores its
+// data in under the top-level volumes of docker-compose.yaml.
+func bindDockerVolume(p *project, dbType domain.DbType) error {
+	volume := dbType.GetDockerVolume()
+	if volume == "" {
+		return nil
+	}
+
+	body, err := os.ReadFile(p.path(dockerComposeFile))
+	if err != nil {
+		return err
+	}
+	compose := string(body)
+
+	if regexp.MustCompile(`(?m)^  ` + regexp.QuoteMeta(volume) + `:`).MatchString(compose) {
+		return nil
+	}
+	if strings.Contains(compose, "\nvolumes:\n") {
+		return p.replaceInFile(dockerComposeFile, "\nvolumes:\n", "\nvolumes:\n  "+volume+":\n")
+	}
+	if strings.Contains(compose, "\nnetworks:") {
+		return p.replaceInFile(dockerComposeFile, "\nnetworks:", "\nvolumes:\n  "+volume+":\n\nnetworks:")
+	}
+
+	return p.appendToFile(dockerComposeFile, []byte("\nvolumes:\n  "+volume+":\n"))
+}
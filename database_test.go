@@ -0,0 +1,181 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+func TestBindDatabase(t *testing.T) {
+	tests := []struct {
+		name       string
+		primary    domain.DbType
+		added      domain.DbType
+		configFld  string
+		repoFld    string
+		connect    string
+		dependency string
+	}{
+		{
+			name:       "primary postgres",
+			primary:    domain.DBTypePostgres,
+			added:      domain.DBTypePostgres,
+			configFld:  "DB",
+			repoFld:    "db",
+			connect:    "db, err := pgxpool.NewClient(ctx, appConfig.DB)",
+			dependency: "      - db\n",
+		},
+		{
+			name:       "mysql next to postgres",
+			primary:    domain.DBTypePostgres,
+			added:      domain.DBTypeMySQL,
+			configFld:  "MysqlDB",
+			repoFld:    "mysqlDB",
+			connect:    "mysqlDB, err := mysql.NewClient(ctx, appConfig.MysqlDB)",
+			dependency: "      - mysql\n",
+		},
+		{
+			name:       "postgres next to sqlite",
+			primary:    domain.DBTypeSQLite,
+			added:      domain.DBTypePostgres,
+			configFld:  "PsqlDB",
+			repoFld:    "psqlDB",
+			connect:    "psqlDB, err := pgxpool.NewClient(ctx, appConfig.PsqlDB)",
+			dependency: "    depends_on:\n      - pgxpool\n",
+		},
+		{
+			name:       "mongo next to mysql",
+			primary:    domain.DBTypeMySQL,
+			added:      domain.DBTypeMongo,
+			configFld:  "MongoDB",
+			repoFld:    "mongoDB",
+			connect:    "mongoDB, err := mongo.NewClient(ctx, appConfig.MongoDB)",
+			dependency: "      - mongo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, p := newTestProject(t, tt.primary)
+			if err := bindDatabase(p, app, tt.primary); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			configField, repoField, err := databaseFields(p, tt.added)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if configField != tt.configFld || repoField != tt.repoFld {
+				t.Fatalf("unexpected fields: %s/%s, want %s/%s", configField, repoField, tt.configFld, tt.repoFld)
+			}
+
+			if err := bindDatabase(p, app, tt.added); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			bound := projectFiles(t, p)
+
+			// The client is created before the repo and passed to it
+			run := bound[appRunFile]
+			connect := strings.Index(run, tt.connect)
+			newRepo := strings.Index(run, "repo := repo.NewRepo(")
+			if connect < 0 || newRepo < 0 || connect > newRepo {
+				t.Fatalf("expected %q before repo.NewRepo:\n%s", tt.connect, run)
+			}
+			if !strings.Contains(run[newRepo:], tt.repoFld+")") {
+				t.Fatalf("expected %s to be passed to repo.NewRepo:\n%s", tt.repoFld, run)
+			}
+
+			// Once wired, the database keeps its names
+			configField, repoField, err = databaseFields(p, tt.added)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if configField != tt.configFld || repoField != tt.repoFld {
+				t.Fatalf("unexpected fields after binding: %s/%s", configField, repoField)
+			}
+
+			// The clients retry with the copy of manager/connect
+			if _, ok := bound["pkg/connect/connect.go"]; !ok {
+				t.Fatal("expected pkg/connect to be written")
+			}
+			for file, content := range bound {
+				if strings.Contains(content, "github.com/MH-KodaCore/goarm") {
+					t.Fatalf("%s imports goarm:\n%s", file, content)
+				}
+			}
+
+			if app := appService(bound[dockerComposeFile]); !strings.Contains(app, tt.dependency) {
+				t.Fatalf("expected %q in the app service:\n%s", tt.dependency, app)
+			}
+
+			// Binding the same database again changes nothing
+			if err := bindDatabase(p, app, tt.added); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			for file, content := range projectFiles(t, p) {
+				if content != bound[file] {
+					t.Fatalf("binding %s twice changed %s:\n%s\nwant:\n%s", tt.added, file, content, bound[file])
+				}
+			}
+		})
+	}
+}
+
+// newTestProject renders a gin project with docker on dbType in a temporary
+// directory, without binding the database yet.
+func newTestProject(t *testing.T, dbType domain.DbType) (domain.App, *project) {
+	t.Helper()
+
+	app := domain.App{
+		Name:      "shop",
+		Module:    "github.com/acme/shop",
+		Framework: domain.FrameworkTypeGin,
+		DbType:    dbType,
+		Features:  []domain.Feature{domain.FeatureDocker},
+	}.WithDefaults()
+
+	p := newProject(t.TempDir())
+	p.dryRun = true
+	if err := createProjectFiles(p, app); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return app, p
+}
+
+// projectFiles returns the content of every file of p by its relative path.
+func projectFiles(t *testing.T, p *project) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	err := filepath.WalkDir(p.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(p.dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return files
+}
+
+// appService returns the app service of a docker-compose.yaml.
+func appService(compose string) string {
+	return appServiceBlock.FindString(compose)
+}
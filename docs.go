@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/manager"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Names used when the OpenAPI document is wired into a project.
+const (
+	docsPackage = "openapi"
+	docsField   = "Docs"
+	docsParam   = "docs"
+	docsSpecURL = "/openapi.json"
+	docsUIURL   = "/docs"
+)
+
+// bindOpenAPI adds pkg/openapi to the project: BindRoutes gets a docs
+// parameter documenting every route already registered, app.Run serves the
+// document at /openapi.json and Swagger UI at /docs, and every env file gets
+// a docs section which is disabled in prod. Steps already applied are skipped.
+func bindOpenAPI(p *project, app domain.App) error {
+	dialect, err := utils.HandlerDialect(app.Framework)
+	if err != nil {
+		return err
+	}
+	pkg := manager.ManagePackage(docsPackage)
+	pkgPath := path.Join(app.Module, "pkg", docsPackage)
+
+	// ───── Step 1: Write Go source files ─────
+	for name, content := range pkg.GetFiles() {
+		rel := path.Join("pkg", docsPackage, name)
+		if p.exists(rel) {
+			continue
+		}
+		if err := p.writeFile(rel, content); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", rel, err)
+		}
+	}
+
+	// ───── Step 2: Append config to env files ─────
+	configKey := regexp.MustCompile(`(?m)^docs:`)
+	for _, env := range app.Envs {
+		configPath := path.Join("etc", env+".yaml")
+
+		body, err := os.ReadFile(p.path(configPath))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", configPath, err)
+		}
+		if configKey.Match(body) {
+			continue
+		}
+
+		config := strings.Replace(string(pkg.GetConfig()), "@title", utils.ProjectDir(app.Module), 1)
+		if env == "prod" {
+			config = strings.Replace(config, "enabled: true", "enabled: false", 1)
+		}
+		if err := p.appendToFile(configPath, []byte(config)); err != nil {
+			return fmt.Errorf("failed to append config to %q: %w", configPath, err)
+		}
+	}
+
+	// ───── Step 3: Add field to AppConfig struct ─────
+	appField := fmt.Sprintf("%s %s.Config `mapstructure:\"%s\" yaml:\"%s\"`", docsField, docsPackage, docsParam, docsParam)
+	if err := p.appendFieldStruct(appStructFile, "AppConfigs", appField); err != nil {
+		return fmt.Errorf("failed to append field to AppConfigs struct: %w", err)
+	}
+	if err := p.addImport(appStructFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to app.go: %w", err)
+	}
+
+	// ───── Step 4: Document the registered routes in BindRoutes ─────
+	documented, err := utils.HasFuncParam(p.path(routesFile), "BindRoutes", docsParam)
+	if err != nil {
+		return err
+	}
+	if !documented {
+		if err := p.addImport(routesFile, pkgPath); err != nil {
+			return fmt.Errorf("failed to add import to routes.go: %w", err)
+		}
+		if err := p.appendFuncArgument(routesFile, "BindRoutes", docsParam, "*"+docsPackage+".Spec"); err != nil {
+			return fmt.Errorf("failed to append argument to BindRoutes: %w", err)
+		}
+		if err := documentRoutes(p, app, dialect, func(utils.Endpoint) bool { return true }); err != nil {
+			return err
+		}
+	}
+
+	// ───── Step 5: Serve the document from the app ─────
+	if err := p.addImport(appRunFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to app/build.go: %w", err)
+	}
+	if dialect.WrapHTTPImport != "" {
+		if err := p.addImport(appRunFile, dialect.WrapHTTPImport); err != nil {
+			return fmt.Errorf("failed to add import to app/build.go: %w", err)
+		}
+	}
+
+	newSpec := fmt.Sprintf("%s := %s.New(appConfig.%s)", docsParam, docsPackage, docsField)
+	if err := p.insertStatement(appRunFile, "Run", "handler.BindRoutes(", newSpec); err != nil {
+		return fmt.Errorf("failed to create the OpenAPI document in app/build.go: %w", err)
+	}
+	if err := p.addCallArgument(appRunFile, "handler.BindRoutes", docsParam); err != nil {
+		return fmt.Errorf("failed to pass the OpenAPI document to BindRoutes: %w", err)
+	}
+
+	serve := fmt.Sprintf("if %s.Enabled() {\n%s\n%s\n}", docsParam,
+		dialect.Route(dialect.RouterVar, "GET", docsSpecURL, fmt.Sprintf(dialect.WrapHTTP, docsParam+".SpecHandler()")),
+		dialect.Route(dialect.RouterVar, "GET", docsUIURL, fmt.Sprintf(dialect.WrapHTTP, fmt.Sprintf("%s.UIHandler(%q)", docsParam, docsSpecURL))),
+	)
+	if err := p.appendStatement(appRunFile, "Run", serve); err != nil {
+		return fmt.Errorf("failed to serve the OpenAPI document in app/build.go: %w", err)
+	}
+
+	return nil
+}
+
+// documentRoutes adds a docs.Add statement to BindRoutes for every route
+// keep accepts, reading the request and response types from its handler.
+func documentRoutes(p *project, app domain.App, dialect utils.Dialect, keep func(utils.Endpoint) bool) error {
+	api, err := utils.AnalyzeProject(p.dir, dialect)
+	if err != nil {
+		return fmt.Errorf("failed to read the routes: %w", err)
+	}
+
+	for _, endpoint := range api.Endpoints {
+		if !keep(endpoint) {
+			continue
+		}
+
+		request, requestPkg := docsValue(endpoint.Request)
+		response, responsePkg := docsValue(endpoint.Response)
+		for _, imported := range []string{requestPkg, responsePkg} {
+			if imported == "" {
+				continue
+			}
+			if err := p.addImport(routesFile, path.Join(app.Module, "internal", imported)); err != nil {
+				return fmt.Errorf("failed to add import to routes.go: %w", err)
+			}
+		}
+
+		if err := p.appendStatement(routesFile, "BindRoutes", docsStatement(endpoint.Method, endpoint.Path, request, response)); err != nil {
+			return fmt.Errorf("failed to document %s %s: %w", endpoint.Method, endpoint.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// docsStatement returns the statement documenting a route in BindRoutes.
+func docsStatement(method, routePath, request, response string) string {
+	return fmt.Sprintf("%s.Add(%q, %q, %s, %s)", docsParam, method, routePath, request, response)
+}
+
+// docsValue returns a Go expression of the type described by schema for
+// openapi.Spec.Add and the internal package it needs, e.g. "domain.Product{}"
+// and "domain". Types that can't be written from routes.go give "nil".
+func docsValue(schema *utils.TypeSchema) (string, string) {
+	if schema == nil {
+		return "nil", ""
+	}
+
+	switch schema.Kind {
+	case "string":
+		return `""`, ""
+	case "integer", "number":
+		return "0", ""
+	case "boolean":
+		return "false", ""
+	case "array":
+		if item, pkg := docsValue(schema.Items); strings.HasSuffix(item, "{}") {
+			return "[]" + item, pkg
+		}
+	case "object":
+		pkg, name, ok := strings.Cut(schema.Name, ".")
+		switch {
+		case !ok:
+		case pkg == "handler":
+			return name + "{}", ""
+		case pkg == "domain":
+			return schema.Name + "{}", pkg
+		}
+	}
+
+	return "nil", ""
+}
@@ -1,10 +1,141 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 // App holds metadata about the application.
 type App struct {
-	Name      string
-	DbType    DbType
-	Framework FrameworkType
+	// Name is the directory the project is generated in.
+	Name string
+	// Module is the Go module path, e.g. github.com/acme/billing.
+	Module     string
+	DbType     DbType
+	DataAccess DataAccess
+	Cache      CacheType
+	Framework  FrameworkType
+	Features   []Feature
+	Host       string
+	Port       string
+	Envs       []string
+}
+
+// Default values for the optional App settings.
+const (
+	DefaultHost = "0.0.0.0"
+	DefaultPort = "8080"
+)
+
+// DefaultEnvs lists the environments generated when none are configured.
+var DefaultEnvs = []string{"dev", "local", "prod"}
+
+// WithDefaults returns a copy of app where every unset optional value is
+// replaced by its default.
+func (a App) WithDefaults() App {
+	if a.DataAccess == "" {
+		a.DataAccess = DataAccessRaw
+	}
+	if a.Cache == "" {
+		a.Cache = CacheTypeNone
+	}
+	if a.Features == nil {
+		a.Features = append([]Feature(nil), DefaultFeatures...)
+	}
+	if a.Host == "" {
+		a.Host = DefaultHost
+	}
+	if a.Port == "" {
+		a.Port = DefaultPort
+	}
+	if len(a.Envs) == 0 {
+		a.Envs = append([]string(nil), DefaultEnvs...)
+	}
+
+	return a
+}
+
+// HasFeature reports whether the feature is enabled for the app.
+func (a App) HasFeature(feature Feature) bool {
+	for _, f := range a.Features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckFeatures reports settings that can't be generated together: GORM and
+// ent need an SQL database, and so does sqlc, which queries the driver client
+// GORM and ent replace. Unset values aren't checked.
+func (a App) CheckFeatures() error {
+	if a.DataAccess != "" && a.DataAccess != DataAccessRaw && a.DbType != "" && !a.DbType.IsSQL() {
+		return fmt.Errorf("%s needs an SQL database, %s isn't one", a.DataAccess.ShortName(), a.DbType)
+	}
+	if a.HasFeature(FeatureSQLC) && a.DbType != "" && !a.DbType.IsSQL() {
+		return fmt.Errorf("%s needs an SQL database, %s isn't one", FeatureSQLC, a.DbType)
+	}
+	if a.HasFeature(FeatureSQLC) && a.DataAccess != "" && a.DataAccess != DataAccessRaw {
+		return fmt.Errorf("%s can't be used with %s: it queries the driver client, use the %s data access", FeatureSQLC, a.DataAccess.ShortName(), DataAccessRaw.ShortName())
+	}
+
+	return nil
+}
+
+// Feature represents an optional part of the generated project.
+type Feature string
+
+const (
+	FeatureDocker  Feature = "docker"
+	FeatureLinter  Feature = "linter"
+	FeatureOpenAPI Feature = "openapi"
+	FeatureGRPC    Feature = "grpc"
+	FeatureSQLC    Feature = "sqlc"
+	FeatureJWT     Feature = "jwt"
+	FeatureMetrics Feature = "metrics"
+)
+
+// SupportedFeatures lists all available features.
+var SupportedFeatures = []Feature{
+	FeatureDocker,
+	FeatureLinter,
+	FeatureOpenAPI,
+	FeatureGRPC,
+	FeatureSQLC,
+	FeatureJWT,
+	FeatureMetrics,
+}
+
+// DefaultFeatures lists the features enabled when none are configured.
+var DefaultFeatures = []Feature{
+	FeatureDocker,
+	FeatureLinter,
+}
+
+// Files returns the template files that only exist when the feature is enabled.
+func (f Feature) Files() []string {
+	switch f {
+	case FeatureDocker:
+		return []string{"Dockerfile", ".dockerignore", "docker-compose.yaml"}
+	case FeatureLinter:
+		return []string{".golangci.yml"}
+	default:
+		return nil
+	}
+}
+
+// ParseFeature resolves a feature from its name. Matching is case-insensitive.
+func ParseFeature(value string) (Feature, error) {
+	names := make([]string, 0, len(SupportedFeatures))
+	for _, f := range SupportedFeatures {
+		if strings.EqualFold(value, string(f)) {
+			return f, nil
+		}
+		names = append(names, string(f))
+	}
+
+	return "", fmt.Errorf("unsupported feature %q (supported: %s)", value, strings.Join(names, ", "))
 }
 
 // FrameworkType represents a supported web framework type.
@@ -14,20 +145,27 @@ type FrameworkType string
 type DbType string
 
 const (
-	FrameworkTypeGin   FrameworkType = "Gin"
-	FrameworkTypeFiber FrameworkType = "Fiber"
+	FrameworkTypeGin    FrameworkType = "Gin"
+	FrameworkTypeFiber  FrameworkType = "Fiber"
+	FrameworkTypeEcho   FrameworkType = "Echo"
+	FrameworkTypeChi    FrameworkType = "Chi"
+	FrameworkTypeStdlib FrameworkType = "net/http (ServeMux)"
 )
 
 const (
 	DBTypePostgres DbType = "Postgres (pgxpool)"
 	DBTypeMySQL    DbType = "MySql"
 	DBTypeSQLite   DbType = "Sqlite"
+	DBTypeMongo    DbType = "MongoDB"
 )
 
 // SupportedFrameworkTypes lists all available framework types.
 var SupportedFrameworkTypes = []FrameworkType{
 	FrameworkTypeGin,
 	FrameworkTypeFiber,
+	FrameworkTypeEcho,
+	FrameworkTypeChi,
+	FrameworkTypeStdlib,
 }
 
 // SupportedDatabaseTypes lists all available database types.
@@ -35,6 +173,7 @@ var SupportedDatabaseTypes = []DbType{
 	DBTypePostgres,
 	DBTypeMySQL,
 	DBTypeSQLite,
+	DBTypeMongo,
 }
 
 // ToDirectory returns the directory name for this FrameworkType.
@@ -44,6 +183,28 @@ func (f FrameworkType) ToDirectory() string {
 		return "gin"
 	case FrameworkTypeFiber:
 		return "fiber"
+	case FrameworkTypeEcho:
+		return "echo"
+	case FrameworkTypeChi:
+		return "chi"
+	case FrameworkTypeStdlib:
+		return "stdlib"
+	default:
+		return ""
+	}
+}
+
+// ShortName returns the name used to select this DbType on the command line.
+func (d DbType) ShortName() string {
+	switch d {
+	case DBTypeMySQL:
+		return "mysql"
+	case DBTypeSQLite:
+		return "sqlite"
+	case DBTypePostgres:
+		return "postgres"
+	case DBTypeMongo:
+		return "mongo"
 	default:
 		return ""
 	}
@@ -58,12 +219,15 @@ func (d DbType) ToCoreDatabase() string {
 		return "sqlite"
 	case DBTypePostgres:
 		return "pgxpool"
+	case DBTypeMongo:
+		return "mongo"
 	default:
 		return ""
 	}
 }
 
-// ToCoreDatabase returns the internal key used for this DbType (e.g., for folder or driver names).
+// ToCoreConfig returns the prefix of the AppConfigs and Repo fields of this
+// DbType when it isn't the first database of a project, e.g. "Mysql" for MysqlDB.
 func (d DbType) ToCoreConfig() string {
 	switch d {
 	case DBTypeMySQL:
@@ -72,12 +236,15 @@ func (d DbType) ToCoreConfig() string {
 		return "Sqlite"
 	case DBTypePostgres:
 		return "Psql"
+	case DBTypeMongo:
+		return "Mongo"
 	default:
 		return ""
 	}
 }
 
-// ToCoreDatabase returns the internal key used for this DbType (e.g., for folder or driver names).
+// PackagePath returns the import path of the client package of this DbType
+// (e.g., "database/sql" or "github.com/jackc/pgx/v5/pgxpool").
 func (d DbType) PackagePath() string {
 	switch d {
 	case DBTypeMySQL:
@@ -86,12 +253,15 @@ func (d DbType) PackagePath() string {
 		return "database/sql"
 	case DBTypePostgres:
 		return "github.com/jackc/pgx/v5/pgxpool"
+	case DBTypeMongo:
+		return "go.mongodb.org/mongo-driver/v2/mongo"
 	default:
 		return ""
 	}
 }
 
-// ToCoreDatabase returns the internal key used for this DbType (e.g., for folder or driver names).
+// PackageVal returns the client type of this DbType as written with its
+// package name (e.g., "sql.DB" or "pgxpool.Pool").
 func (d DbType) PackageVal() string {
 	switch d {
 	case DBTypeMySQL:
@@ -100,6 +270,8 @@ func (d DbType) PackageVal() string {
 		return "sql.DB"
 	case DBTypePostgres:
 		return "pgxpool.Pool"
+	case DBTypeMongo:
+		return "mongo.Database"
 	default:
 		return ""
 	}
@@ -135,6 +307,22 @@ func (d DbType) GetDockerConfig() string {
       - "3306:3306"
     networks:
       - app-network
+`
+	case DBTypeMongo:
+		return `
+  db:
+    image: mongo:7
+    container_name: mongo_db
+    environment:
+      MONGO_INITDB_DATABASE: your_database
+      MONGO_INITDB_ROOT_USERNAME: your_username
+      MONGO_INITDB_ROOT_PASSWORD: your_password
+    ports:
+      - "27017:27017"
+    volumes:
+      - mongodata:/data/db
+    networks:
+      - app-network
 `
 	case DBTypeSQLite:
 		return `
@@ -152,7 +340,7 @@ func (d DbType) GetDockerConfig() string {
 
 func (d DbType) GetDockerDependence() string {
 	switch d {
-	case DBTypePostgres, DBTypeMySQL:
+	case DBTypePostgres, DBTypeMySQL, DBTypeMongo:
 		return `depends_on:
       - db`
 	default:
@@ -160,6 +348,203 @@ func (d DbType) GetDockerDependence() string {
 	}
 }
 
+// IsSQL reports whether this DbType is queried with SQL.
+func (d DbType) IsSQL() bool {
+	switch d {
+	case DBTypePostgres, DBTypeMySQL, DBTypeSQLite:
+		return true
+	default:
+		return false
+	}
+}
+
+// SqlcEngine returns the sqlc engine of this DbType, or "" if sqlc doesn't
+// support it.
+func (d DbType) SqlcEngine() string {
+	switch d {
+	case DBTypePostgres:
+		return "postgresql"
+	case DBTypeMySQL:
+		return "mysql"
+	case DBTypeSQLite:
+		return "sqlite"
+	default:
+		return ""
+	}
+}
+
+// SqlcPackage returns the sql_package sqlc generates code for, matching the
+// client of PackagePath.
+func (d DbType) SqlcPackage() string {
+	switch d.PackagePath() {
+	case "github.com/jackc/pgx/v5/pgxpool":
+		return "pgx/v5"
+	case "database/sql":
+		return "database/sql"
+	default:
+		return ""
+	}
+}
+
+// RepoPackage returns the import path of the client Repo holds for this DbType
+// with the data access style; module is the module of the project. Databases
+// that aren't SQL always use their driver.
+func (d DbType) RepoPackage(module string, style DataAccess) string {
+	if !d.IsSQL() {
+		return d.PackagePath()
+	}
+
+	switch style {
+	case DataAccessGORM:
+		return "gorm.io/gorm"
+	case DataAccessEnt:
+		return module + "/internal/repo/ent"
+	default:
+		return d.PackagePath()
+	}
+}
+
+// RepoVal returns the type of the client Repo holds for this DbType with the
+// data access style, e.g. "gorm.DB".
+func (d DbType) RepoVal(style DataAccess) string {
+	if !d.IsSQL() {
+		return d.PackageVal()
+	}
+
+	switch style {
+	case DataAccessGORM:
+		return "gorm.DB"
+	case DataAccessEnt:
+		return "ent.Client"
+	default:
+		return d.PackageVal()
+	}
+}
+
+// GetDockerVolume returns the named volume the docker-compose service of this
+// DbType stores its data in, or "" if it has none.
+func (d DbType) GetDockerVolume() string {
+	switch d {
+	case DBTypeMongo:
+		return "mongodata"
+	default:
+		return ""
+	}
+}
+
+// CacheType represents a supported cache.
+type CacheType string
+
+const (
+	CacheTypeNone   CacheType = "None"
+	CacheTypeRedis  CacheType = "Redis"
+	CacheTypeMemory CacheType = "In-memory"
+)
+
+// SupportedCacheTypes lists all available cache types.
+var SupportedCacheTypes = []CacheType{
+	CacheTypeNone,
+	CacheTypeRedis,
+	CacheTypeMemory,
+}
+
+// ShortName returns the name used to select this CacheType on the command line.
+func (c CacheType) ShortName() string {
+	switch c {
+	case CacheTypeNone:
+		return "none"
+	case CacheTypeRedis:
+		return "redis"
+	case CacheTypeMemory:
+		return "memory"
+	default:
+		return ""
+	}
+}
+
+// GetDockerConfig returns the docker-compose service of this CacheType, or ""
+// if it runs inside the app.
+func (c CacheType) GetDockerConfig() string {
+	switch c {
+	case CacheTypeRedis:
+		return `
+  redis:
+    image: redis:7
+    container_name: redis_cache
+    ports:
+      - "6379:6379"
+    healthcheck:
+      test: ["CMD", "redis-cli", "ping"]
+      interval: 5s
+      timeout: 3s
+      retries: 5
+    networks:
+      - app-network
+`
+	default:
+		return ""
+	}
+}
+
+// ParseCacheType resolves a cache from its label ("Redis") or short name
+// ("redis"). Matching is case-insensitive.
+func ParseCacheType(value string) (CacheType, error) {
+	names := make([]string, 0, len(SupportedCacheTypes))
+	for _, c := range SupportedCacheTypes {
+		if strings.EqualFold(value, string(c)) || strings.EqualFold(value, c.ShortName()) {
+			return c, nil
+		}
+		names = append(names, c.ShortName())
+	}
+
+	return "", fmt.Errorf("unsupported cache %q (supported: %s)", value, strings.Join(names, ", "))
+}
+
+// DataAccess represents how the repositories of a project query its SQL
+// databases.
+type DataAccess string
+
+const (
+	DataAccessRaw  DataAccess = "Raw driver"
+	DataAccessGORM DataAccess = "GORM"
+	DataAccessEnt  DataAccess = "ent"
+)
+
+// SupportedDataAccess lists all available data access styles.
+var SupportedDataAccess = []DataAccess{
+	DataAccessRaw,
+	DataAccessGORM,
+	DataAccessEnt,
+}
+
+// ShortName returns the name used to select this DataAccess on the command line.
+func (a DataAccess) ShortName() string {
+	switch a {
+	case DataAccessRaw:
+		return "raw"
+	case DataAccessGORM:
+		return "gorm"
+	case DataAccessEnt:
+		return "ent"
+	default:
+		return ""
+	}
+}
+
+// ParseDataAccess resolves a data access style from its label ("Raw driver")
+// or short name ("raw"). Matching is case-insensitive.
+func ParseDataAccess(value string) (DataAccess, error) {
+	names := make([]string, 0, len(SupportedDataAccess))
+	for _, a := range SupportedDataAccess {
+		if strings.EqualFold(value, string(a)) || strings.EqualFold(value, a.ShortName()) {
+			return a, nil
+		}
+		names = append(names, a.ShortName())
+	}
+
+	return "", fmt.Errorf("unsupported data access %q (supported: %s)", value, strings.Join(names, ", "))
+}
+
 // ParseDbTypeFromLabel returns the core database key (e.g., "mysql", "sqlite", "pgxpool")
 // from a human-readable label like "MySql" or "Postgres (pgxpool)".
 // If the label is unknown, it returns an empty string.
@@ -167,3 +552,33 @@ func ParseDbTypeFromLabel(label string) string {
 	dbType := DbType(label)
 	return dbType.ToCoreDatabase()
 }
+
+// ParseFrameworkType resolves a framework from its label ("Gin") or directory
+// name ("gin"). Matching is case-insensitive.
+func ParseFrameworkType(value string) (FrameworkType, error) {
+	names := make([]string, 0, len(SupportedFrameworkTypes))
+	for _, f := range SupportedFrameworkTypes {
+		if strings.EqualFold(value, string(f)) || strings.EqualFold(value, f.ToDirectory()) {
+			return f, nil
+		}
+		names = append(names, f.ToDirectory())
+	}
+
+	return "", fmt.Errorf("unsupported framework %q (supported: %s)", value, strings.Join(names, ", "))
+}
+
+// ParseDbType resolves a database from its label ("Postgres (pgxpool)"),
+// core key ("pgxpool") or short name ("postgres"). Matching is case-insensitive.
+func ParseDbType(value string) (DbType, error) {
+	names := make([]string, 0, len(SupportedDatabaseTypes))
+	for _, d := range SupportedDatabaseTypes {
+		if strings.EqualFold(value, string(d)) ||
+			strings.EqualFold(value, d.ToCoreDatabase()) ||
+			strings.EqualFold(value, d.ShortName()) {
+			return d, nil
+		}
+		names = append(names, d.ShortName())
+	}
+
+	return "", fmt.Errorf("unsupported database %q (supported: %s)", value, strings.Join(names, ", "))
+}
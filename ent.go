@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+// entTemplatesDir holds the ent schema of the sample notes table with its
+// generated code and Makefile targets.
+const entTemplatesDir = "templates/ent"
+
+// bindEnt adds ent for dbType: internal/repo/ent with the schema of the
+// notes table in schema/ and its generated client, the migration creating
+// the table and the ent Makefile targets. Steps already applied are skipped.
+func bindEnt(p *project, app domain.App, dbType domain.DbType) error {
+	if !dbType.IsSQL() {
+		return fmt.Errorf("ent needs an SQL database, %s isn't one", dbType)
+	}
+
+	// The schema of the database is still managed with the migrations
+	if err := bindMigrations(p, app, dbType); err != nil {
+		return err
+	}
+	if err := bindNotesMigration(p, app, dbType); err != nil {
+		return err
+	}
+
+	// ───── Step 1: Write the schema and generated files ─────
+	err := fs.WalkDir(templatesFS, entTemplatesDir, func(source string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimPrefix(source, entTemplatesDir+"/")
+		if rel == makefile || p.exists(rel) {
+			return nil
+		}
+
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+
+		// The generated code names its package in comments as well
+		content = renderTemplate(app, rel, content)
+		content = []byte(strings.ReplaceAll(string(content), " "+templateModule+"/", " "+app.Module+"/"))
+
+		return p.renderFile(rel, source, content)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the ent files: %w", err)
+	}
+
+	// ───── Step 2: Add the codegen targets to the Makefile ─────
+	return appendMakeTargets(p, entTemplatesDir, "ent")
+}
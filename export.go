@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+const exportUsage = `Usage:
+  goarm export <format> [flags]
+
+Formats:
+  postman  Postman v2.1 collection of the routes in BindRoutes
+  openapi  OpenAPI 3 document of the routes in BindRoutes and their types
+`
+
+// exportCommand handles `goarm export`, which describes an existing project.
+func exportCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, exportUsage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "postman":
+		return exportPostmanCommand(args[1:])
+	case "openapi":
+		return exportOpenAPICommand(args[1:])
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, exportUsage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q\n\n%s", args[0], exportUsage)
+		return exitUsage
+	}
+}
+
+// exportPostmanCommand handles `goarm export postman`.
+func exportPostmanCommand(args []string) int {
+	flags := flag.NewFlagSet("export postman", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+	env := flags.String("env", "local", "environment whose etc/<env>.yaml sets {{baseUrl}}")
+	output := flags.String("o", "", "output file (default: stdout)")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm export postman [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	api, err := analyzeProject(p, app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	baseURL, err := projectBaseURL(p, *env)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	collection := utils.ExportPostman(utils.ProjectDir(app.Module), baseURL, api.Endpoints)
+	content, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := writeOutput(*output, append(content, '\n')); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// exportOpenAPICommand handles `goarm export openapi`.
+func exportOpenAPICommand(args []string) int {
+	flags := flag.NewFlagSet("export openapi", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+	env := flags.String("env", "local", "environment whose etc/<env>.yaml sets the server URL")
+	output := flags.String("o", "", "output file (default: openapi.yaml in the project directory, - for stdout)")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm export openapi [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if len(positional) > 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	api, err := analyzeProject(p, app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	serverURL, err := projectBaseURL(p, *env)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(utils.BuildOpenAPI(utils.ProjectDir(app.Module), serverURL, api)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	switch *output {
+	case "":
+		*output = p.path("openapi.yaml")
+	case "-":
+		*output = ""
+	}
+	if err := writeOutput(*output, buf.Bytes()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if *output != "" {
+		fmt.Printf("✅ OpenAPI document of %d routes written to %s.\n", len(api.Endpoints), *output)
+	}
+	return exitOK
+}
+
+// analyzeProject reads the routes of p and the types their handlers bind and
+// respond with.
+func analyzeProject(p *project, app domain.App) (utils.API, error) {
+	if app.Framework == "" {
+		return utils.API{}, errors.New("can't detect the web framework of the project from its go.mod")
+	}
+
+	dialect, err := utils.HandlerDialect(app.Framework)
+	if err != nil {
+		return utils.API{}, err
+	}
+
+	api, err := utils.AnalyzeProject(p.dir, dialect)
+	if err != nil {
+		return utils.API{}, fmt.Errorf("failed to read the routes: %w", err)
+	}
+
+	return api, nil
+}
+
+// projectBaseURL returns the URL the project listens on in env, read from
+// the app section of etc/<env>.yaml.
+func projectBaseURL(p *project, env string) (string, error) {
+	configPath := path.Join("etc", env+".yaml")
+	body, err := os.ReadFile(p.path(configPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %q: %w", configPath, err)
+	}
+
+	var config struct {
+		App struct {
+			Host string `yaml:"host"`
+			Port string `yaml:"port"`
+		} `yaml:"app"`
+	}
+	if err := yaml.Unmarshal(body, &config); err != nil {
+		return "", fmt.Errorf("failed to parse %q: %w", configPath, err)
+	}
+
+	host, port := config.App.Host, config.App.Port
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	if port == "" {
+		port = domain.DefaultPort
+	}
+
+	return "http://" + net.JoinHostPort(host, port), nil
+}
+
+// writeOutput writes content to file, or to stdout if file is empty.
+func writeOutput(file string, content []byte) error {
+	if file == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+
+	return os.WriteFile(file, content, 0o644)
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+const generateUsage = `Usage:
+  goarm generate <generator> [flags]
+
+Generators:
+  resource      domain type, repository, service, handlers and routes of an entity
+  from-postman  handler stubs and routes of the requests in a Postman collection
+`
+
+// Project files touched when code is generated into a project.
+const (
+	handlerDir           = "internal/handler"
+	repoInterfaceFile    = "internal/service/interface.go"
+	serviceInterfaceFile = handlerDir + "/interface.go"
+	routesFile           = handlerDir + "/routes.go"
+	handlerFile          = handlerDir + "/build.go"
+	domainErrorsFile     = "internal/domain/errors.go"
+	repoHelpersFile      = "internal/repo/helpers.go"
+)
+
+// generateCommand handles `goarm generate`, which adds code to an existing project.
+func generateCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, generateUsage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "resource":
+		return generateResourceCommand(args[1:])
+	case "from-postman":
+		return generateFromPostmanCommand(args[1:])
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, generateUsage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown generator %q\n\n%s", args[0], generateUsage)
+		return exitUsage
+	}
+}
+
+// generateResourceCommand handles `goarm generate resource`.
+func generateResourceCommand(args []string) int {
+	flags := flag.NewFlagSet("generate resource", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	fields := flags.String("fields", "", `space separated fields, e.g. "title:string price:int64"`)
+	database := flags.String("db", "", "database the repository uses when the project has several ("+databaseNames()+")")
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm generate resource <Name> --fields \"name:type ...\" [flags]\n\n"+
+			"Field types: string, bool, int, int32, int64, float32, float64, time\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if len(positional) != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	resource, err := utils.ParseResource(positional[0], *fields)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	if err := generateResource(p, app, resource, *database); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error generating %s: %v\n", resource.Name, err)
+		return exitFailure
+	}
+
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to run 'go mod tidy': %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ %s generated, routes are served under %s.\n", resource.Name, resource.Path())
+	return exitOK
+}
+
+// generateResource writes the domain type, repository, service and handler
+// files of resource and wires them into the interfaces and routes of p.
+func generateResource(p *project, app domain.App, resource utils.Resource, database string) error {
+	if app.Framework == "" {
+		return errors.New("can't detect the web framework of the project from its go.mod")
+	}
+
+	dbType, repoField, err := resourceDatabase(p, database)
+	if err != nil {
+		return err
+	}
+
+	file := resource.File()
+	layers := []struct {
+		dir    string
+		render func() ([]byte, error)
+	}{
+		{"internal/domain", func() ([]byte, error) { return utils.RenderResourceDomain(resource) }},
+		{"internal/repo", func() ([]byte, error) {
+			return utils.RenderResourceRepo(app.Module, resource, repoField, dbType)
+		}},
+		{"internal/service", func() ([]byte, error) { return utils.RenderResourceService(app.Module, resource) }},
+		{"internal/handler", func() ([]byte, error) {
+			return utils.RenderResourceHandler(app.Module, resource, app.Framework)
+		}},
+	}
+
+	// ───── Step 1: Render every file before touching the project ─────
+	files := map[string][]byte{}
+	for _, layer := range layers {
+		rel := path.Join(layer.dir, file)
+		if p.exists(rel) {
+			return fmt.Errorf("%s already exists", rel)
+		}
+
+		content, err := layer.render()
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", rel, err)
+		}
+		files[rel] = content
+	}
+
+	if !p.exists(domainErrorsFile) {
+		files[domainErrorsFile] = []byte(utils.ErrNotFoundSource)
+	}
+	if !p.exists(repoHelpersFile) {
+		helpers, err := utils.RenderRepoHelpers(app.Module)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", repoHelpersFile, err)
+		}
+		files[repoHelpersFile] = helpers
+	}
+
+	// ───── Step 2: Write the files ─────
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := p.writeFile(name, files[name]); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", name, err)
+		}
+	}
+
+	// ───── Step 3: Extend RepoInterface and ServiceInterface ─────
+	for _, target := range []struct{ file, name string }{
+		{repoInterfaceFile, "RepoInterface"},
+		{serviceInterfaceFile, "ServiceInterface"},
+	} {
+		for _, importPath := range []string{"context", path.Join(app.Module, "internal/domain")} {
+			if err := p.addImport(target.file, importPath); err != nil {
+				return fmt.Errorf("failed to add import to %s: %w", target.file, err)
+			}
+		}
+
+		for _, method := range resource.Methods() {
+			if err := p.appendInterfaceMethod(target.file, target.name, method); err != nil {
+				return fmt.Errorf("failed to extend %s: %w", target.name, err)
+			}
+		}
+	}
+
+	// ───── Step 4: Register the routes ─────
+	dialect, err := utils.HandlerDialect(app.Framework)
+	if err != nil {
+		return err
+	}
+	for _, route := range resource.Routes(dialect) {
+		if err := p.appendStatement(routesFile, "BindRoutes", route); err != nil {
+			return fmt.Errorf("failed to register route in BindRoutes: %w", err)
+		}
+	}
+
+	// ───── Step 5: Document the routes if the project serves OpenAPI ─────
+	documented, err := utils.HasFuncParam(p.path(routesFile), "BindRoutes", docsParam)
+	if err != nil || !documented {
+		return err
+	}
+	if err := p.addImport(routesFile, path.Join(app.Module, "internal/domain")); err != nil {
+		return fmt.Errorf("failed to add import to routes.go: %w", err)
+	}
+
+	item, list := "domain."+resource.Name+"{}", "[]domain."+resource.Name+"{}"
+	for _, doc := range [][4]string{
+		{"POST", resource.Path(), item, item},
+		{"GET", resource.Path(), "nil", list},
+		{"GET", resource.Path() + "/:id", "nil", item},
+		{"PUT", resource.Path() + "/:id", item, item},
+		{"DELETE", resource.Path() + "/:id", "nil", "nil"},
+	} {
+		if err := p.appendStatement(routesFile, "BindRoutes", docsStatement(doc[0], doc[1], doc[2], doc[3])); err != nil {
+			return fmt.Errorf("failed to document %s %s: %w", doc[0], doc[1], err)
+		}
+	}
+
+	return nil
+}
+
+// resourceDatabase picks the database a generated repository queries and
+// returns the Repo field holding its client. A project with several databases
+// needs the choice to be made with --db.
+func resourceDatabase(p *project, database string) (domain.DbType, string, error) {
+	fields, err := utils.StructFields(p.path(appStructFile), "AppConfigs")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read AppConfigs struct: %w", err)
+	}
+
+	var wired []domain.DbType
+	for _, dbType := range domain.SupportedDatabaseTypes {
+		for _, typ := range fields {
+			if typ == dbType.ToCoreDatabase()+".Config" {
+				wired = append(wired, dbType)
+			}
+		}
+	}
+
+	var dbType domain.DbType
+	switch {
+	case database != "":
+		if dbType, err = domain.ParseDbType(database); err != nil {
+			return "", "", err
+		}
+		found := false
+		for _, d := range wired {
+			found = found || d == dbType
+		}
+		if !found {
+			return "", "", fmt.Errorf("%s is not wired into the project, run 'goarm add db %s' first", dbType, dbType.ShortName())
+		}
+	case len(wired) == 1:
+		dbType = wired[0]
+	case len(wired) == 0:
+		return "", "", errors.New("the project has no database")
+	default:
+		names := make([]string, 0, len(wired))
+		for _, d := range wired {
+			names = append(names, d.ShortName())
+		}
+		return "", "", fmt.Errorf("the project has several databases, choose one with --db (%s)", strings.Join(names, ", "))
+	}
+
+	// The generated repositories are written in SQL
+	if !dbType.IsSQL() {
+		return "", "", fmt.Errorf("resources can't be generated for %s yet, choose an SQL database with --db", dbType)
+	}
+
+	_, repoField, err := databaseFields(p, dbType)
+	if err != nil {
+		return "", "", err
+	}
+
+	// sqlc replaces the client field with the generated queries
+	repoFields, err := utils.StructFields(p.path(repoFile), "Repo")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read Repo struct: %w", err)
+	}
+	client, ok := repoFields[repoField]
+	if !ok {
+		return "", "", fmt.Errorf("Repo queries %s with sqlc, add the queries to queries/ and run 'make sqlc'", dbType)
+	}
+	if client != "*"+dbType.PackageVal() {
+		return "", "", fmt.Errorf("resources are written for the driver client, Repo.%s is a %s", repoField, client)
+	}
+
+	return dbType, repoField, nil
+}
+
+// generateFromPostmanCommand handles `goarm generate from-postman`.
+func generateFromPostmanCommand(args []string) int {
+	flags := flag.NewFlagSet("generate from-postman", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+
+	dir := flags.String("dir", ".", "project directory")
+
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage:\n  goarm generate from-postman <collection.json> [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if len(positional) != 1 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	collection, err := utils.ParseApi(positional[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	p, app, err := openProject(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+
+	routes, err := generateFromPostman(p, app, collection)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: error generating handlers: %v\n", err)
+		return exitFailure
+	}
+
+	fmt.Printf("✅ %d handlers generated in %s.\n", routes, path.Join(handlerDir, collection.FileName()))
+	return exitOK
+}
+
+// generateFromPostman writes a handler stub for every request of collection
+// and registers it in BindRoutes, one router group per folder. Requests whose
+// method and path are already routed are skipped. It returns the number of
+// generated handlers.
+func generateFromPostman(p *project, app domain.App, collection utils.PostmanCollection) (int, error) {
+	if app.Framework == "" {
+		return 0, errors.New("can't detect the web framework of the project from its go.mod")
+	}
+
+	dialect, err := utils.HandlerDialect(app.Framework)
+	if err != nil {
+		return 0, err
+	}
+
+	file := path.Join(handlerDir, collection.FileName())
+	if p.exists(file) {
+		return 0, fmt.Errorf("%s already exists", file)
+	}
+
+	bound, err := utils.BoundRoutes(p.path(routesFile), "BindRoutes", dialect)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read BindRoutes: %w", err)
+	}
+	routed := map[string]bool{}
+	for _, route := range bound {
+		routed[route.Method+" "+route.Path] = true
+	}
+
+	group := collection.Routes().Filter(func(route utils.Route) bool {
+		if routed[route.Method+" "+route.FullPath] {
+			p.logf("⏭️  Skipping %s %s, it is already routed\n", route.Method, route.FullPath)
+			return false
+		}
+		return true
+	})
+
+	routes := group.AllRoutes()
+	if len(routes) == 0 {
+		return 0, errors.New("the collection has no requests that aren't routed yet")
+	}
+
+	// ───── Step 1: Write the handler stubs ─────
+	methods, err := utils.TypeMethods(p.path(handlerDir), "Handler")
+	if err != nil {
+		return 0, err
+	}
+	for _, route := range routes {
+		if methods[route.Handler] {
+			return 0, fmt.Errorf("handler %s of %s %s already exists", route.Handler, route.Method, route.FullPath)
+		}
+	}
+
+	content, err := utils.RenderHandlerStubs(routes, dialect)
+	if err != nil {
+		return 0, fmt.Errorf("failed to render %s: %w", file, err)
+	}
+	if err := p.writeFile(file, content); err != nil {
+		return 0, fmt.Errorf("failed to write file %q: %w", file, err)
+	}
+
+	// ───── Step 2: Register the routes ─────
+	for _, stmt := range group.Statements(dialect, dialect.RouterVar) {
+		if err := p.appendStatement(routesFile, "BindRoutes", stmt); err != nil {
+			return 0, fmt.Errorf("failed to register route in BindRoutes: %w", err)
+		}
+	}
+
+	// ───── Step 3: Document the routes if the project serves OpenAPI ─────
+	documented, err := utils.HasFuncParam(p.path(routesFile), "BindRoutes", docsParam)
+	if err != nil {
+		return 0, err
+	}
+	for _, route := range routes {
+		if !documented {
+			break
+		}
+		if err := p.appendStatement(routesFile, "BindRoutes", docsStatement(route.Method, route.FullPath, "nil", "nil")); err != nil {
+			return 0, fmt.Errorf("failed to document %s %s: %w", route.Method, route.FullPath, err)
+		}
+	}
+
+	return len(routes), nil
+}
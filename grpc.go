@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+// Names used when the gRPC transport is wired into a project.
+const (
+	grpcTemplatesDir = "templates/grpc"
+	grpcPackageDir   = "internal/transport/grpc"
+	grpcField        = "GRPC"
+	grpcConfigKey    = "grpc"
+	grpcPort         = "9090"
+	makefile         = "Makefile"
+	dockerfile       = "Dockerfile"
+)
+
+// bindGRPC adds the gRPC transport to the project: the proto sources with
+// their generated code, buf configs and Makefile targets, a server in
+// internal/transport/grpc and a grpc section in every env file. app.Run
+// starts the server next to the HTTP one, or instead of it when grpc.http
+// is false. Steps already applied are skipped.
+func bindGRPC(p *project, app domain.App) error {
+	templateConfig, err := templatesFS.ReadFile(grpcTemplatesDir + "/config.yaml")
+	if err != nil {
+		return err
+	}
+	makeTargets, err := templatesFS.ReadFile(grpcTemplatesDir + "/" + makefile)
+	if err != nil {
+		return err
+	}
+
+	// ───── Step 1: Write proto, generated and server files ─────
+	err = fs.WalkDir(templatesFS, grpcTemplatesDir, func(source string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimPrefix(source, grpcTemplatesDir+"/")
+		if rel == "config.yaml" || rel == makefile || p.exists(rel) {
+			return nil
+		}
+
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		return p.renderFile(rel, source, renderTemplate(app, rel, content))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the gRPC files: %w", err)
+	}
+
+	// ───── Step 2: Append config to env files ─────
+	configKey := regexp.MustCompile(`(?m)^` + grpcConfigKey + `:`)
+	for _, env := range app.Envs {
+		configPath := path.Join("etc", env+".yaml")
+
+		body, err := os.ReadFile(p.path(configPath))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", configPath, err)
+		}
+		if configKey.Match(body) {
+			continue
+		}
+
+		config := "\n" + string(templateConfig)
+		if env == "prod" {
+			config = strings.Replace(config, "reflection: true", "reflection: false", 1)
+		}
+		if err := p.appendToFile(configPath, []byte(config)); err != nil {
+			return fmt.Errorf("failed to append config to %q: %w", configPath, err)
+		}
+	}
+
+	// ───── Step 3: Add field to AppConfig struct ─────
+	pkgPath := path.Join(app.Module, grpcPackageDir)
+	appField := fmt.Sprintf("%s grpc.Config `mapstructure:\"%s\" yaml:\"%s\"`", grpcField, grpcConfigKey, grpcConfigKey)
+	if err := p.appendFieldStruct(appStructFile, "AppConfigs", appField); err != nil {
+		return fmt.Errorf("failed to append field to AppConfigs struct: %w", err)
+	}
+	if err := p.addImport(appStructFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to app.go: %w", err)
+	}
+
+	// ───── Step 4: Add the codegen targets to the Makefile ─────
+	if p.exists(makefile) {
+		body, err := os.ReadFile(p.path(makefile))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", makefile, err)
+		}
+		if !regexp.MustCompile(`(?m)^proto:`).Match(body) {
+			if err := p.appendToFile(makefile, makeTargets); err != nil {
+				return fmt.Errorf("failed to append targets to %q: %w", makefile, err)
+			}
+		}
+	}
+
+	// ───── Step 5: Start the server from the app ─────
+	if err := p.addImport(appRunFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to app/build.go: %w", err)
+	}
+
+	// Next to HTTP, both servers run until either of them stops and Run
+	// returns once both are shut down
+	body, err := os.ReadFile(p.path(appRunFile))
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", appRunFile, err)
+	}
+	returns := regexp.MustCompile(`(?m)^\treturn (serve\(ctx, .*\))$`).FindAllSubmatch(body, -1)
+	if len(returns) == 0 {
+		return fmt.Errorf("no return serve(...) in Run of %s", appRunFile)
+	}
+	serveHTTP := string(returns[len(returns)-1][1])
+
+	serve := fmt.Sprintf(`if appConfig.%[1]s.Enabled {
+	grpcServer := grpc.NewServer(appConfig.%[1]s, service)
+	if !appConfig.%[1]s.HTTP {
+		return serve(ctx, grpcServer.Run, grpcServer.Shutdown, appConfig.App.ShutdownTimeout)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- serve(ctx, grpcServer.Run, grpcServer.Shutdown, appConfig.App.ShutdownTimeout)
+	}()
+	go func() {
+		errs <- %[2]s
+	}()
+
+	var first error
+	for range 2 {
+		if err := <-errs; err != nil && first == nil {
+			first = err
+		}
+		cancel()
+	}
+	return first
+}`, grpcField, serveHTTP)
+	if err := p.appendStatement(appRunFile, "Run", serve); err != nil {
+		return fmt.Errorf("failed to start the gRPC server in app/build.go: %w", err)
+	}
+
+	// ───── Step 6: Publish the port from docker ─────
+	return exposeDockerPort(p, grpcPort)
+}
+
+// exposeDockerPort publishes port next to the HTTP port in the Dockerfile and
+// docker-compose.yaml, if the project has them.
+func exposeDockerPort(p *project, port string) error {
+	if p.exists(dockerfile) {
+		body, err := os.ReadFile(p.path(dockerfile))
+		if err != nil {
+			return err
+		}
+
+		expose := regexp.MustCompile(`(?m)^EXPOSE .*$`).Find(body)
+		if expose != nil && !strings.Contains(string(expose), port) {
+			if err := p.replaceInFile(dockerfile, string(expose), string(expose)+" "+port); err != nil {
+				return err
+			}
+		}
+	}
+
+	if p.exists(dockerComposeFile) {
+		body, err := os.ReadFile(p.path(dockerComposeFile))
+		if err != nil {
+			return err
+		}
+
+		mapping := fmt.Sprintf("- %q", port+":"+port)
+		ports := regexp.MustCompile(`(?m)^([ \t]*)ports:[ \t]*\n`).FindSubmatch(body)
+		if ports != nil && !strings.Contains(string(body), mapping) {
+			indent := string(ports[1]) + "  "
+			if err := p.replaceInFile(dockerComposeFile, string(ports[0]), string(ports[0])+indent+mapping+"\n"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// appendMakeTargets appends the Makefile of templatesDir to the Makefile of
+// the project unless it already has target. Projects without a Makefile are
+// left alone.
+func appendMakeTargets(p *project, templatesDir, target string) error {
+	if !p.exists(makefile) {
+		return nil
+	}
+
+	makeTargets, err := templatesFS.ReadFile(templatesDir + "/" + makefile)
+	if err != nil {
+		return err
+	}
+	body, err := os.ReadFile(p.path(makefile))
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", makefile, err)
+	}
+	if regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(target) + `:`).Match(body) {
+		return nil
+	}
+	if err := p.appendToFile(makefile, makeTargets); err != nil {
+		return fmt.Errorf("failed to append targets to %q: %w", makefile, err)
+	}
+
+	return nil
+}
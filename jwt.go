@@ -0,0 +1,183 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Names used when JWT auth is wired into a project.
+const (
+	jwtTemplatesDir  = "templates/jwt"
+	jwtFrameworksDir = "frameworks"
+	jwtPackageDir    = "pkg/jwt"
+	jwtField         = "JWT"
+	jwtConfigKey     = "jwt"
+	jwtParam         = "tokens"
+	authRoutesPrefix = "/auth/"
+)
+
+// bindJWT adds JWT auth to the project: pkg/jwt signing and verifying HS256
+// or RS256 tokens, domain.Claims, an Auth middleware with login, refresh and
+// me handlers registered under /auth, and a jwt section in every env file.
+// Every non-prod env gets a random secret, prod reads it from JWT_SECRET.
+// Steps already applied are skipped.
+func bindJWT(p *project, app domain.App) error {
+	dialect, err := utils.HandlerDialect(app.Framework)
+	if err != nil {
+		return err
+	}
+	templateConfig, err := templatesFS.ReadFile(jwtTemplatesDir + "/config.yaml")
+	if err != nil {
+		return err
+	}
+
+	// ───── Step 1: Write the package, claims and handlers ─────
+	frameworkDir := path.Join(jwtFrameworksDir, app.Framework.ToDirectory()) + "/"
+	err = fs.WalkDir(templatesFS, jwtTemplatesDir, func(source string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimPrefix(source, jwtTemplatesDir+"/")
+		if rel == "config.yaml" {
+			return nil
+		}
+
+		// Only the handlers of the framework are copied
+		if strings.HasPrefix(rel, jwtFrameworksDir+"/") {
+			if !strings.HasPrefix(rel, frameworkDir) {
+				return nil
+			}
+			rel = strings.TrimPrefix(rel, frameworkDir)
+		}
+		if p.exists(rel) {
+			return nil
+		}
+
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		return p.renderFile(rel, source, renderTemplate(app, rel, content))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the JWT files: %w", err)
+	}
+
+	// ───── Step 2: Append config to env files ─────
+	configKey := regexp.MustCompile(`(?m)^` + jwtConfigKey + `:`)
+	for _, env := range app.Envs {
+		configPath := path.Join("etc", env+".yaml")
+
+		body, err := os.ReadFile(p.path(configPath))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", configPath, err)
+		}
+		if configKey.Match(body) {
+			continue
+		}
+
+		secret := ""
+		if env != "prod" {
+			if secret, err = randomSecret(); err != nil {
+				return err
+			}
+		}
+		config := strings.Replace("\n"+string(templateConfig), "@secret", secret, 1)
+		config = strings.Replace(config, "@issuer", utils.ProjectDir(app.Module), 1)
+		if err := p.appendToFile(configPath, []byte(config)); err != nil {
+			return fmt.Errorf("failed to append config to %q: %w", configPath, err)
+		}
+	}
+
+	// ───── Step 3: Add field to AppConfig struct ─────
+	pkgPath := path.Join(app.Module, jwtPackageDir)
+	appField := fmt.Sprintf("%s jwt.Config `mapstructure:\"%s\" yaml:\"%s\"`", jwtField, jwtConfigKey, jwtConfigKey)
+	if err := p.appendFieldStruct(appStructFile, "AppConfigs", appField); err != nil {
+		return fmt.Errorf("failed to append field to AppConfigs struct: %w", err)
+	}
+	if err := p.addImport(appStructFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to app.go: %w", err)
+	}
+
+	// ───── Step 4: Update Handler struct and NewHandler ─────
+	if err := p.addImport(handlerFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to handler/build.go: %w", err)
+	}
+	if err := p.appendFieldStruct(handlerFile, "Handler", jwtParam+" *jwt.Manager"); err != nil {
+		return fmt.Errorf("failed to append field to Handler struct: %w", err)
+	}
+	if err := p.appendFuncArgument(handlerFile, "NewHandler", jwtParam, "*jwt.Manager"); err != nil {
+		return fmt.Errorf("failed to append argument to NewHandler function: %w", err)
+	}
+	if err := p.addReturnField(handlerFile, "NewHandler", jwtParam); err != nil {
+		return fmt.Errorf("failed to set constructor return value: %w", err)
+	}
+
+	// ───── Step 5: Register the auth routes ─────
+	me := "h.Auth(h.Me)"
+	if app.Framework == domain.FrameworkTypeGin || app.Framework == domain.FrameworkTypeFiber {
+		me = "h.Auth, h.Me"
+	}
+	routes := []string{
+		dialect.Route(dialect.RouterVar, "POST", authRoutesPrefix+"login", "h.Login"),
+		dialect.Route(dialect.RouterVar, "POST", authRoutesPrefix+"refresh", "h.Refresh"),
+		dialect.Route(dialect.RouterVar, "GET", authRoutesPrefix+"me", me),
+	}
+	for _, route := range routes {
+		if err := p.appendStatement(routesFile, "BindRoutes", route); err != nil {
+			return fmt.Errorf("failed to register %s: %w", route, err)
+		}
+	}
+
+	documented, err := utils.HasFuncParam(p.path(routesFile), "BindRoutes", docsParam)
+	if err != nil {
+		return err
+	}
+	if documented {
+		isAuth := func(endpoint utils.Endpoint) bool { return strings.HasPrefix(endpoint.Path, authRoutesPrefix) }
+		if err := documentRoutes(p, app, dialect, isAuth); err != nil {
+			return err
+		}
+	}
+
+	// ───── Step 6: Load the keys in the app ─────
+	for _, imported := range []string{"fmt", pkgPath} {
+		if err := p.addImport(appRunFile, imported); err != nil {
+			return fmt.Errorf("failed to add import to app/build.go: %w", err)
+		}
+	}
+	statements := []string{
+		fmt.Sprintf("%s, err := jwt.New(appConfig.%s)", jwtParam, jwtField),
+		"if err != nil {\n\treturn fmt.Errorf(\"can't load the jwt keys: %w\", err)\n}",
+	}
+	for _, stmt := range statements {
+		if err := p.insertStatement(appRunFile, "Run", "handler.BindRoutes(", stmt); err != nil {
+			return fmt.Errorf("failed to load the jwt keys in app/build.go: %w", err)
+		}
+	}
+	if err := p.addCallArgument(appRunFile, "handler.NewHandler", jwtParam); err != nil {
+		return fmt.Errorf("failed to pass the jwt keys to handler.NewHandler: %w", err)
+	}
+
+	return nil
+}
+
+// randomSecret returns a hex encoded 256 bit HS256 secret.
+func randomSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate the jwt secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -2,143 +2,235 @@ package main
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
-	"os/exec"
-	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/MH-KodaCore/goarm/domain"
-	"github.com/MH-KodaCore/goarm/manager"
-	"github.com/MH-KodaCore/goarm/utils"
 )
 
 //go:embed templates/**/*
 var templatesFS embed.FS
 
 func main() {
-	app := utils.OpenForm()
+	os.Exit(run(os.Args[1:]))
+}
+
+// generateOptions controls how a generated project is written to disk.
+type generateOptions struct {
+	// force allows replacing a non-empty project directory.
+	force bool
+	// dryRun prints what would be generated instead of writing the project.
+	dryRun bool
+	// json prints the dry run plan as JSON.
+	json bool
+}
 
-	if err := createProjectFiles(app.Name, app.Framework); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating project files: %v\n", err)
-		os.Exit(1)
+// generateProject creates the project described by app in its directory,
+// binds the selected database and initializes the Go module.
+//
+// The project is rendered into a temporary directory next to the target and
+// only moved into place once every step succeeded, so a failure never leaves
+// a half-written project behind.
+func generateProject(app domain.App, opts generateOptions) error {
+	app = app.WithDefaults()
+	target := filepath.Clean(app.Name)
+
+	// A dry run writes nothing, so the target may hold anything
+	if !opts.dryRun {
+		if err := checkTarget(target, opts.force); err != nil {
+			return err
+		}
 	}
 
-	if err := bindDependencies(app.Name, app.DbType); err != nil {
-		fmt.Fprintf(os.Stderr, "Error initializing go.mod: %v\n", err)
-		os.Exit(1)
+	stageParent := filepath.Dir(target)
+	if opts.dryRun {
+		stageParent = ""
 	}
 
-	if err := initializeGoMod(app.Name, app.Name); err != nil {
-		fmt.Fprintf(os.Stderr, "Error initializing go.mod: %v\n", err)
-		os.Exit(1)
+	stage, err := os.MkdirTemp(stageParent, "."+filepath.Base(target)+".goarm-*")
+	if err != nil {
+		return fmt.Errorf("error creating staging directory: %w", err)
 	}
+	defer os.RemoveAll(stage)
+
+	p := newProject(stage)
+	p.dryRun = opts.dryRun
 
-	if err := utils.UpdatePackageNameOnGCI(app.Name); err != nil {
-		fmt.Fprintf(os.Stderr, "Error updating package name for .golangci.yml file: %v\n", err)
-		os.Exit(1)
+	if err := renderProject(p, app); err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		return printPlan(os.Stdout, p, app, opts.json)
 	}
 
-	fmt.Println("✅ Project setup completed successfully.")
+	if err := commitProject(stage, target); err != nil {
+		return fmt.Errorf("error moving project into %q: %w", target, err)
+	}
+
+	return nil
 }
 
-func bindDependencies(appName string, dbType domain.DbType) error {
-	coreDB := dbType.ToCoreDatabase()
-	dbValue := dbType.PackageVal()
-	manager := manager.Manage(coreDB)
+// renderProject runs every generation step for app inside the project.
+func renderProject(p *project, app domain.App) error {
+	if err := os.Chmod(p.dir, 0o755); err != nil {
+		return fmt.Errorf("error preparing project directory: %w", err)
+	}
 
-	baseDir := path.Join(appName, "pkg", coreDB)
+	if err := createProjectFiles(p, app); err != nil {
+		return fmt.Errorf("error creating project files: %w", err)
+	}
 
-	// ───── Step 1: Create base directory ─────
-	if err := os.MkdirAll(baseDir, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create directory %q: %w", baseDir, err)
+	if err := bindDependencies(p, app); err != nil {
+		return fmt.Errorf("error binding dependencies: %w", err)
 	}
 
-	// ───── Step 2: Write Go source files ─────
-	files := map[string][]byte{
-		"init.go": manager.Database.GetInit(),
+	if err := initializeGoMod(p, app.Module); err != nil {
+		return fmt.Errorf("error initializing go.mod: %w", err)
 	}
-	for name, content := range files {
-		path := path.Join(baseDir, name)
-		if err := os.WriteFile(path, content, os.ModePerm); err != nil {
-			return fmt.Errorf("failed to write file %q: %w", path, err)
+
+	if app.HasFeature(domain.FeatureLinter) {
+		if err := p.updatePackageNameOnGCI(app.Module); err != nil {
+			return fmt.Errorf("error updating package name for .golangci.yml file: %w", err)
 		}
 	}
 
-	// ───── Step 3: Append config to env files ─────
-	envFiles := []string{"dev.yaml", "local.yaml", "prod.yaml"}
-	for _, env := range envFiles {
-		configPath := path.Join(appName, "etc", env)
-		if err := utils.AppendToFile(configPath, manager.Database.GetConfig()); err != nil {
-			return fmt.Errorf("failed to append config to %q: %w", configPath, err)
+	return nil
+}
+
+// checkTarget refuses to generate into an existing non-empty directory
+// unless force is set.
+func checkTarget(target string, force bool) error {
+	entries, err := os.ReadDir(target)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("can't use %q as project directory: %w", target, err)
+	case len(entries) > 0 && !force:
+		return fmt.Errorf("directory %q already exists and is not empty (use --force to overwrite it)", target)
+	}
+
+	return nil
+}
+
+// commitProject moves the staged project to target. An existing target is
+// moved aside first and restored if the move fails.
+func commitProject(stage, target string) error {
+	entries, err := os.ReadDir(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.Rename(stage, target)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		if err := os.Remove(target); err != nil {
+			return err
 		}
+		return os.Rename(stage, target)
+	}
+
+	backup, err := os.MkdirTemp(filepath.Dir(target), "."+filepath.Base(target)+".goarm-old-*")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(backup); err != nil {
+		return err
+	}
+
+	if err := os.Rename(target, backup); err != nil {
+		return err
 	}
 
-	// ───── Step 4: Add field to AppConfig struct ─────
-	appStructPath := path.Join(appName, "internal", "domain", "app.go")
-	appField := fmt.Sprintf(`DB %s.Config `+"`mapstructure:\"%s\" yaml:\"%s\"`", coreDB, coreDB, coreDB)
-	if err := utils.AppendFieldStruct(appStructPath, "AppConfigs", appField); err != nil {
-		return fmt.Errorf("failed to append field to AppConfigs struct: %w", err)
+	if err := os.Rename(stage, target); err != nil {
+		if restoreErr := os.Rename(backup, target); restoreErr != nil {
+			return fmt.Errorf("%w (previous project kept in %q: %v)", err, backup, restoreErr)
+		}
+		return err
 	}
 
-	// ───── Step 5: Add import for DB package ─────
-	if err := utils.AddImportToFile(appStructPath, path.Join(appName, "pkg", coreDB)); err != nil {
-		return fmt.Errorf("failed to add import to app.go: %w", err)
+	return os.RemoveAll(backup)
+}
+
+// bindDependencies wires the selected database, cache and features into the project.
+func bindDependencies(p *project, app domain.App) error {
+	if app.DataAccess != domain.DataAccessRaw && !app.DbType.IsSQL() {
+		return fmt.Errorf("%s needs an SQL database, %s isn't one", app.DataAccess, app.DbType)
 	}
 
-	// ───── Step 6: Update Repo struct ─────
-	repoFile := path.Join(appName, "internal", "repo", "build.go")
-	if err := utils.AddImportToFile(repoFile, dbType.PackagePath()); err != nil {
-		return fmt.Errorf("failed to add DB import to repo/build.go: %w", err)
+	if err := bindDatabase(p, app, app.DbType); err != nil {
+		return err
 	}
 
-	repoField := fmt.Sprintf("db *%s", dbValue)
-	if err := utils.AppendFieldStruct(repoFile, "Repo", repoField); err != nil {
-		return fmt.Errorf("failed to append field to Repo struct: %w", err)
+	if err := bindMigrations(p, app, app.DbType); err != nil {
+		return fmt.Errorf("error binding migrations: %w", err)
 	}
 
-	// ───── Step 7: Update NewRepo constructor ─────
-	if err := utils.AppendFuncArgument(repoFile, "NewRepo", "db", "*"+dbValue); err != nil {
-		return fmt.Errorf("failed to append argument to NewRepo function: %w", err)
+	if app.DataAccess == domain.DataAccessEnt {
+		if err := bindEnt(p, app, app.DbType); err != nil {
+			return fmt.Errorf("error binding ent: %w", err)
+		}
 	}
-	if err := utils.AddReturnFieldToConstructor(repoFile, "NewRepo", "db"); err != nil {
-		return fmt.Errorf("failed to set constructor return value: %w", err)
+
+	if app.HasFeature(domain.FeatureSQLC) {
+		if err := bindSQLC(p, app, app.DbType); err != nil {
+			return fmt.Errorf("error binding sqlc: %w", err)
+		}
 	}
 
-	// ───── Step 8: Update app run layer ─────
-	appRunFile := path.Join(appName, "internal", "app", "build.go")
-	if err := utils.AddImportToFile(appRunFile, path.Join(appName, "pkg", coreDB)); err != nil {
-		return fmt.Errorf("failed to add DB import to app/build.go: %w", err)
+	if err := bindCache(p, app.Module, app.Envs, app.Cache); err != nil {
+		return fmt.Errorf("error binding cache: %w", err)
 	}
 
-	callArg := fmt.Sprintf("%s.NewClient(appConfig.DB)", coreDB)
-	if err := utils.AddArgumentToFunctionCall(appRunFile, "repo.NewRepo", callArg); err != nil {
-		return fmt.Errorf("failed to inject database client into repo.NewRepo: %w", err)
+	if app.HasFeature(domain.FeatureJWT) {
+		if err := bindJWT(p, app); err != nil {
+			return fmt.Errorf("error binding jwt: %w", err)
+		}
 	}
 
-	dockerComposeFile := fmt.Sprintf("%s/docker-compose.yaml", appName)
-	fileBody, err := os.ReadFile(dockerComposeFile)
-	if err != nil {
-		return fmt.Errorf("failed to open docker-compose file: %w", err)
+	if app.HasFeature(domain.FeatureOpenAPI) {
+		if err := bindOpenAPI(p, app); err != nil {
+			return fmt.Errorf("error binding openapi: %w", err)
+		}
 	}
 
-	// Replace the "@db" placeholder with the actual DB config
-	fileContent := strings.Replace(string(fileBody), "@db", dbType.GetDockerConfig(), 1)
-	fileContent = strings.Replace(fileContent, "@dn", dbType.GetDockerDependence(), 1)
+	if app.HasFeature(domain.FeatureMetrics) {
+		if err := bindMetrics(p, app); err != nil {
+			return fmt.Errorf("error binding metrics: %w", err)
+		}
+	}
 
-	if err := os.WriteFile(dockerComposeFile, []byte(fileContent), 0o644); err != nil {
-		return fmt.Errorf("failed to write to docker-compose file: %w", err)
+	if app.HasFeature(domain.FeatureGRPC) {
+		if err := bindGRPC(p, app); err != nil {
+			return fmt.Errorf("error binding grpc: %w", err)
+		}
 	}
 
 	return nil
 }
 
 // createProjectFiles copies template files to the new project directory,
-// replacing the string "template" with the project name in the file contents and paths.
-func createProjectFiles(projectName string, framework domain.FrameworkType) error {
-	templatesDir := "templates/" + framework.ToDirectory()
+// rewriting the "templates" import prefix to the module path of the project.
+// Files of disabled features are skipped and etc/<env>.yaml is written once per env.
+func createProjectFiles(p *project, app domain.App) error {
+	templatesDir := "templates/" + app.Framework.ToDirectory()
+
+	skipped := map[string]bool{}
+	for _, feature := range domain.SupportedFeatures {
+		if app.HasFeature(feature) {
+			continue
+		}
+		for _, file := range feature.Files() {
+			skipped[file] = true
+		}
+	}
+
 	err := fs.WalkDir(templatesFS, templatesDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -149,64 +241,110 @@ func createProjectFiles(projectName string, framework domain.FrameworkType) erro
 			return nil
 		}
 
-		// Read file content
-		content, err := templatesFS.ReadFile(path)
-		if err != nil {
-			return err
-		}
-
-		// Replace "template" with the project name inside file content
-		updatedContent := strings.ReplaceAll(string(content), "templates", projectName)
-
 		// Calculate relative path and target destination path
 		relativePath, err := filepath.Rel(templatesDir, path)
 		if err != nil {
 			return err
 		}
-		targetPath := filepath.Join(projectName, relativePath)
+		relativePath = filepath.ToSlash(relativePath)
+
+		// Env configs are rendered below from the env template
+		if skipped[relativePath] || filepath.Dir(relativePath) == "etc" {
+			return nil
+		}
 
-		// Ensure the target directory exists
-		if err := os.MkdirAll(filepath.Dir(targetPath), os.ModePerm); err != nil {
+		// Read file content
+		content, err := templatesFS.ReadFile(path)
+		if err != nil {
 			return err
 		}
 
-		// Write the updated content to the target file
-		return os.WriteFile(targetPath, []byte(updatedContent), os.ModePerm)
+		return p.renderFile(relativePath, path, renderTemplate(app, relativePath, content))
 	})
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Project files created successfully.")
+	envTemplatePath := templatesDir + "/etc/" + envTemplateName
+	envTemplate, err := templatesFS.ReadFile(envTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	for _, env := range app.Envs {
+		relativePath := "etc/" + env + ".yaml"
+		if err := p.renderFile(relativePath, envTemplatePath, renderTemplate(app, relativePath, envTemplate)); err != nil {
+			return err
+		}
+	}
+
+	p.logf("Project files created successfully.\n")
 	return nil
 }
 
-// initializeGoMod runs `go mod init` and `go mod tidy` in the project directory.
-func initializeGoMod(projectDir, moduleName string) error {
-	projectDir = filepath.Clean(projectDir)
+// templateModule is the module path the template files import each other with.
+const templateModule = "templates"
 
-	if err := runCommand(projectDir, "go", "mod", "init", moduleName); err != nil {
-		return fmt.Errorf("failed to run 'go mod init': %w", err)
+// envTemplateName is the etc file used as the base of every generated env config.
+const envTemplateName = "dev.yaml"
+
+// configFlagTemplate is the -config flag declaration in the template cmd/app/main.go.
+const configFlagTemplate = `flag.String("config", "dev", "[prod,dev,locale]")`
+
+// renderTemplate applies the App settings to the content of a template file.
+func renderTemplate(app domain.App, relativePath string, content []byte) []byte {
+	// Point the template imports at the module of the project
+	updatedContent := strings.ReplaceAll(string(content), `"`+templateModule+`/`, `"`+app.Module+`/`)
+
+	switch {
+	case strings.HasPrefix(relativePath, "etc/"):
+		updatedContent = strings.ReplaceAll(updatedContent, `"`+domain.DefaultHost+`"`, fmt.Sprintf("%q", app.Host))
+		updatedContent = strings.ReplaceAll(updatedContent, `"`+domain.DefaultPort+`"`, fmt.Sprintf("%q", app.Port))
+		// Production logs are read by machines
+		if relativePath == "etc/prod.yaml" {
+			updatedContent = strings.Replace(updatedContent, "format: text", "format: json", 1)
+		}
+
+	case relativePath == "Dockerfile", relativePath == "docker-compose.yaml", relativePath == "Makefile":
+		updatedContent = strings.ReplaceAll(updatedContent, domain.DefaultPort, app.Port)
+
+	case relativePath == "cmd/app/main.go", relativePath == "cmd/migrate/main.go":
+		defaultEnv := app.Envs[0]
+		for _, env := range app.Envs {
+			if env == "dev" {
+				defaultEnv = env
+			}
+		}
+		configFlag := fmt.Sprintf(`flag.String("config", %q, "[%s]")`, defaultEnv, strings.Join(app.Envs, ","))
+		updatedContent = strings.Replace(updatedContent, configFlagTemplate, configFlag, 1)
 	}
 
-	if err := runCommand(projectDir, "go", "mod", "tidy"); err != nil {
-		return fmt.Errorf("failed to run 'go mod tidy': %w", err)
+	return []byte(updatedContent)
+}
+
+// writeProjectFile writes content to relativePath inside the project directory.
+func writeProjectFile(dir, relativePath string, content []byte) error {
+	targetPath := filepath.Join(dir, filepath.FromSlash(relativePath))
+
+	// Ensure the target directory exists
+	if err := os.MkdirAll(filepath.Dir(targetPath), os.ModePerm); err != nil {
+		return err
 	}
 
-	fmt.Println("✅ go.mod initialized and tidied.")
-	return nil
+	// Write the updated content to the target file
+	return os.WriteFile(targetPath, content, os.ModePerm)
 }
 
-// runCommand executes a command in a specific directory and prints output.
-func runCommand(dir string, name string, args ...string) error {
-	cmd := exec.Command(name, args...)
-	cmd.Dir = dir
+// initializeGoMod runs `go mod init` and `go mod tidy` in the project directory.
+func initializeGoMod(p *project, moduleName string) error {
+	if err := p.run("go", "mod", "init", moduleName); err != nil {
+		return fmt.Errorf("failed to run 'go mod init': %w", err)
+	}
 
-	output, err := cmd.CombinedOutput()
-	fmt.Printf("▶️ Running: %s %v\n%s\n", name, args, output)
-	if err != nil {
-		return fmt.Errorf("command failed: %w", err)
+	if err := p.run("go", "mod", "tidy"); err != nil {
+		return fmt.Errorf("failed to run 'go mod tidy': %w", err)
 	}
 
+	p.logf("✅ go.mod initialized and tidied.\n")
 	return nil
 }
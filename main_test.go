@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckTarget(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	full := filepath.Join(dir, "full")
+	writeTestFile(t, filepath.Join(full, "main.go"), "package main\n")
+	if err := os.Mkdir(empty, 0o755); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		force   bool
+		wantErr string
+	}{
+		{name: "missing directory", target: filepath.Join(dir, "missing")},
+		{name: "empty directory", target: empty},
+		{name: "non-empty directory", target: full, wantErr: "use --force"},
+		{name: "non-empty directory with force", target: full, force: true},
+		{name: "target is a file", target: filepath.Join(full, "main.go"), wantErr: "can't use"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTarget(tt.target, tt.force)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCommitProject(t *testing.T) {
+	t.Run("missing target", func(t *testing.T) {
+		stage, target := newTestStage(t), filepath.Join(t.TempDir(), "app")
+
+		if err := commitProject(stage, target); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		checkTestFile(t, filepath.Join(target, "go.mod"), "module new\n")
+	})
+
+	t.Run("empty target", func(t *testing.T) {
+		stage, target := newTestStage(t), filepath.Join(t.TempDir(), "app")
+		if err := os.Mkdir(target, 0o755); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if err := commitProject(stage, target); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		checkTestFile(t, filepath.Join(target, "go.mod"), "module new\n")
+	})
+
+	t.Run("non-empty target is replaced", func(t *testing.T) {
+		stage, target := newTestStage(t), filepath.Join(t.TempDir(), "app")
+		writeTestFile(t, filepath.Join(target, "go.mod"), "module old\n")
+		writeTestFile(t, filepath.Join(target, "old.go"), "package old\n")
+
+		if err := commitProject(stage, target); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		checkTestFile(t, filepath.Join(target, "go.mod"), "module new\n")
+		if _, err := os.Stat(filepath.Join(target, "old.go")); !os.IsNotExist(err) {
+			t.Fatalf("expected old.go to be gone, got %v", err)
+		}
+		checkNoBackup(t, target)
+	})
+
+	t.Run("target is restored when the move fails", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "app")
+		writeTestFile(t, filepath.Join(target, "go.mod"), "module old\n")
+
+		// A stage that doesn't exist makes the rename to target fail
+		stage := filepath.Join(t.TempDir(), "missing")
+		if err := commitProject(stage, target); err == nil {
+			t.Fatal("expected an error")
+		}
+		checkTestFile(t, filepath.Join(target, "go.mod"), "module old\n")
+		checkNoBackup(t, target)
+	})
+}
+
+// newTestStage returns a staged project holding a go.mod of module new.
+func newTestStage(t *testing.T) string {
+	t.Helper()
+
+	stage := filepath.Join(t.TempDir(), "stage")
+	writeTestFile(t, filepath.Join(stage, "go.mod"), "module new\n")
+	return stage
+}
+
+// checkNoBackup fails if a backup of target was left next to it.
+func checkNoBackup(t *testing.T, target string) {
+	t.Helper()
+
+	backups, err := filepath.Glob(filepath.Join(filepath.Dir(target), "."+filepath.Base(target)+".goarm-old-*"))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(backups) > 0 {
+		t.Fatalf("unexpected backups: %v", backups)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func checkTestFile(t *testing.T, path, want string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if string(got) != want {
+		t.Fatalf("unexpected content of %s: %q, want %q", path, got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCache keeps the values in a map of the process. Expired values are
+// dropped when they are read.
+type memoryCache struct {
+	mu    sync.RWMutex
+	items map[string]memoryItem
+}
+
+// NewMemory creates an in-memory Cache. It isn't shared between instances of
+// the app, use it for a single instance or in tests.
+func NewMemory() Cache {
+	return &memoryCache{items: map[string]memoryItem{}}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	item, ok := c.items[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrMiss
+	}
+	if !item.expiresAt.IsZero() && time.Now().After(item.expiresAt) {
+		c.mu.Lock()
+		delete(c.items, key)
+		c.mu.Unlock()
+		return nil, ErrMiss
+	}
+
+	return item.value, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	c.items[key] = item
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	delete(c.items, key)
+	c.mu.Unlock()
+
+	return nil
+}
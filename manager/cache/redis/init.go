@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MH-KodaCore/goarm/manager/connect"
+)
+
+type Config struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Password string `json:"password"`
+	DB       int    `json:"db"`
+	// ConnectTimeout bounds every connection attempt, 5s when empty.
+	ConnectTimeout time.Duration `json:"connect_timeout" mapstructure:"connect_timeout"`
+	// ConnectAttempts is how often NewClient tries to reach the server, 5 when empty.
+	ConnectAttempts int `json:"connect_attempts" mapstructure:"connect_attempts"`
+}
+
+// NewClient creates a new Redis client. While the server isn't reachable yet,
+// e.g. when docker-compose boots it next to the app, it retries with a
+// growing delay. It overrides cfg.Host with REDIS_HOST env variable if set.
+func NewClient(ctx context.Context, cfg Config) (*redis.Client, error) {
+	if envHost := os.Getenv("REDIS_HOST"); envHost != "" {
+		cfg.Host = envHost
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ping := func(ctx context.Context) error { return client.Ping(ctx).Err() }
+	if err := connect.Retry(ctx, cfg.ConnectTimeout, cfg.ConnectAttempts, ping); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("can't ping redis: %w", err)
+	}
+
+	return client, nil
+}
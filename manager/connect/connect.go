@@ -0,0 +1,42 @@
+package connect
+
+import (
+	"context"
+	"time"
+)
+
+// Defaults of Retry for an empty timeout or number of attempts.
+const (
+	DefaultTimeout  = 5 * time.Second
+	DefaultAttempts = 5
+)
+
+// Retry calls ping until it succeeds or attempts run out, waiting twice as
+// long after every failure. Each call gets its own timeout. It lets clients
+// wait for a server that isn't reachable yet, e.g. when docker-compose boots
+// it next to the app.
+func Retry(ctx context.Context, timeout time.Duration, attempts int, ping func(context.Context) error) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if attempts <= 0 {
+		attempts = DefaultAttempts
+	}
+
+	delay := 500 * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := ping(attemptCtx)
+		cancel()
+		if err == nil || attempt == attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
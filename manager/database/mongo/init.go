@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/MH-KodaCore/goarm/manager/connect"
+)
+
+type Config struct {
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Database   string `json:"database"`
+	AuthSource string `json:"authsource"`
+	// ConnectTimeout bounds every connection attempt, 5s when empty.
+	ConnectTimeout time.Duration `json:"connect_timeout" mapstructure:"connect_timeout"`
+	// ConnectAttempts is how often NewClient tries to reach the server, 5 when empty.
+	ConnectAttempts int `json:"connect_attempts" mapstructure:"connect_attempts"`
+}
+
+// NewClient connects to MongoDB and returns the configured database. While
+// the server isn't reachable yet, e.g. when docker-compose boots it next to
+// the app, it retries with a growing delay.
+// It overrides cfg.Host with DB_HOST env variable if set.
+func NewClient(ctx context.Context, cfg Config) (*mongo.Database, error) {
+	if envHost := os.Getenv("DB_HOST"); envHost != "" {
+		cfg.Host = envHost
+	}
+
+	authSource := cfg.AuthSource
+	if authSource == "" {
+		authSource = "admin"
+	}
+
+	uri := fmt.Sprintf("mongodb://%s:%d", cfg.Host, cfg.Port)
+	opts := options.Client().ApplyURI(uri)
+	if cfg.Username != "" {
+		opts.SetAuth(options.Credential{
+			Username:   cfg.Username,
+			Password:   cfg.Password,
+			AuthSource: authSource,
+		})
+	}
+
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		return nil, fmt.Errorf("mongo.Connect error: %w", err)
+	}
+
+	ping := func(ctx context.Context) error { return client.Ping(ctx, nil) }
+	if err := connect.Retry(ctx, cfg.ConnectTimeout, cfg.ConnectAttempts, ping); err != nil {
+		_ = client.Disconnect(context.WithoutCancel(ctx))
+		return nil, fmt.Errorf("can't ping mongo: %w", err)
+	}
+
+	return client.Database(cfg.Database), nil
+}
@@ -1,11 +1,15 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/MH-KodaCore/goarm/manager/connect"
 )
 
 type Config struct {
@@ -14,12 +18,17 @@ type Config struct {
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
 	Database string `json:"database"`
+	// ConnectTimeout bounds every connection attempt, 5s when empty.
+	ConnectTimeout time.Duration `json:"connect_timeout" mapstructure:"connect_timeout"`
+	// ConnectAttempts is how often NewClient tries to reach the server, 5 when empty.
+	ConnectAttempts int `json:"connect_attempts" mapstructure:"connect_attempts"`
 }
 
-// NewClient creates a new MySQL client with given config.
-// If environment variable DB_HOST is set, it overrides cfg.Host.
-// Panics on any error.
-func NewClient(cfg Config) *sql.DB {
+// NewClient creates a new MySQL client with given config. While the server
+// isn't reachable yet, e.g. when docker-compose boots it next to the app, it
+// retries with a growing delay. If environment variable DB_HOST is set, it
+// overrides cfg.Host.
+func NewClient(ctx context.Context, cfg Config) (*sql.DB, error) {
 	if envHost := os.Getenv("DB_HOST"); envHost != "" {
 		cfg.Host = envHost
 	}
@@ -34,14 +43,14 @@ func NewClient(cfg Config) *sql.DB {
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		panic(fmt.Sprintf("error opening mysql connection: %v", err))
+		return nil, fmt.Errorf("error opening mysql connection: %w", err)
 	}
 
 	// Test connection with Ping
-	if err := db.Ping(); err != nil {
+	if err := connect.Retry(ctx, cfg.ConnectTimeout, cfg.ConnectAttempts, db.PingContext); err != nil {
 		db.Close()
-		panic(fmt.Sprintf("can't ping mysql: %v", err))
+		return nil, fmt.Errorf("can't ping mysql: %w", err)
 	}
 
-	return db
+	return db, nil
 }
@@ -0,0 +1,29 @@
+package pgxpool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/MH-KodaCore/goarm/manager/connect"
+)
+
+// NewEntDriver returns an ent driver on a database/sql pool of pgx
+// connections, retrying like NewClient while the server isn't reachable yet.
+func NewEntDriver(ctx context.Context, cfg Config) (*entsql.Driver, error) {
+	conn, err := sql.Open("pgx", connString(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("error opening postgres connection: %w", err)
+	}
+
+	if err := connect.Retry(ctx, cfg.ConnectTimeout, cfg.ConnectAttempts, conn.PingContext); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("PostgreSQL ping failed: %w", err)
+	}
+
+	return entsql.OpenDB(dialect.Postgres, conn), nil
+}
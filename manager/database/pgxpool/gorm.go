@@ -0,0 +1,35 @@
+package pgxpool
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/MH-KodaCore/goarm/manager/connect"
+)
+
+// NewGorm opens GORM on a database/sql pool of pgx connections, retrying like
+// NewClient while the server isn't reachable yet.
+func NewGorm(ctx context.Context, cfg Config) (*gorm.DB, error) {
+	conn, err := sql.Open("pgx", connString(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("error opening postgres connection: %w", err)
+	}
+
+	if err := connect.Retry(ctx, cfg.ConnectTimeout, cfg.ConnectAttempts, conn.PingContext); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("PostgreSQL ping failed: %w", err)
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("gorm open error: %w", err)
+	}
+
+	return db, nil
+}
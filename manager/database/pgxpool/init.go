@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/MH-KodaCore/goarm/manager/connect"
 )
 
 type Config struct {
@@ -15,11 +18,32 @@ type Config struct {
 	Port     int    `json:"port"`
 	Database string `json:"database"`
 	SSLMode  string `json:"sslmode"`
+	// ConnectTimeout bounds every connection attempt, 5s when empty.
+	ConnectTimeout time.Duration `json:"connect_timeout" mapstructure:"connect_timeout"`
+	// ConnectAttempts is how often NewClient tries to reach the server, 5 when empty.
+	ConnectAttempts int `json:"connect_attempts" mapstructure:"connect_attempts"`
+}
+
+// NewClient creates a new PostgreSQL connection pool. While the server isn't
+// reachable yet, e.g. when docker-compose boots it next to the app, it retries
+// with a growing delay.
+func NewClient(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, connString(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("pgxpool.New error: %w", err)
+	}
+
+	if err := connect.Retry(ctx, cfg.ConnectTimeout, cfg.ConnectAttempts, pool.Ping); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("PostgreSQL ping failed: %w", err)
+	}
+
+	return pool, nil
 }
 
-// NewClient creates a new PostgreSQL connection pool and panics on failure.
-// It overrides cfg.Host with DB_HOST env variable if set.
-func NewClient(cfg Config) *pgxpool.Pool {
+// connString returns the URL of the database of cfg. It overrides cfg.Host
+// with DB_HOST env variable if set.
+func connString(cfg Config) string {
 	if envHost := os.Getenv("DB_HOST"); envHost != "" {
 		cfg.Host = envHost
 	}
@@ -29,7 +53,7 @@ func NewClient(cfg Config) *pgxpool.Pool {
 		sslmode = "disable"
 	}
 
-	connString := fmt.Sprintf(
+	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.Username,
 		cfg.Password,
@@ -38,16 +62,4 @@ func NewClient(cfg Config) *pgxpool.Pool {
 		cfg.Database,
 		sslmode,
 	)
-
-	pool, err := pgxpool.New(context.Background(), connString)
-	if err != nil {
-		panic(fmt.Sprintf("pgxpool.New error: %v", err))
-	}
-
-	if err := pool.Ping(context.Background()); err != nil {
-		pool.Close()
-		panic(fmt.Sprintf("PostgreSQL ping failed: %v", err))
-	}
-
-	return pool
 }
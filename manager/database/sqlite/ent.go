@@ -0,0 +1,18 @@
+package sqlite
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// NewEntDriver returns an ent driver on top of the connection of NewClient.
+func NewEntDriver(ctx context.Context, cfg Config) (*entsql.Driver, error) {
+	conn, err := NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return entsql.OpenDB(dialect.SQLite, conn), nil
+}
@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewGorm opens GORM on top of the connection of NewClient.
+func NewGorm(ctx context.Context, cfg Config) (*gorm.DB, error) {
+	conn, err := NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: conn}), &gorm.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("gorm open error: %w", err)
+	}
+
+	return db, nil
+}
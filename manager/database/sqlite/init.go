@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -15,36 +16,35 @@ type Config struct {
 
 // NewClient creates a SQLite connection.
 // If the database file or its directory doesn't exist, they will be created.
-// Panics on any failure.
-func NewClient(config Config) *sql.DB {
+func NewClient(ctx context.Context, config Config) (*sql.DB, error) {
 	if config.Path == "" {
-		panic("sqlite config error: database path is empty")
+		return nil, fmt.Errorf("sqlite config error: database path is empty")
 	}
 
 	// Ensure the parent directory exists
 	dir := filepath.Dir(config.Path)
 	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		panic(fmt.Sprintf("sqlite directory creation error: %v", err))
+		return nil, fmt.Errorf("sqlite directory creation error: %w", err)
 	}
 
 	// Ensure the file exists (create if missing)
 	file, err := os.OpenFile(config.Path, os.O_RDWR|os.O_CREATE, 0666)
 	if err != nil {
-		panic(fmt.Sprintf("sqlite file creation/opening error: %v", err))
+		return nil, fmt.Errorf("sqlite file creation/opening error: %w", err)
 	}
 	file.Close()
 
 	// Open DB connection
 	db, err := sql.Open("sqlite3", config.Path)
 	if err != nil {
-		panic(fmt.Sprintf("sqlite open error: %v", err))
+		return nil, fmt.Errorf("sqlite open error: %w", err)
 	}
 
 	// Ping to verify connection is valid
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
 		_ = db.Close()
-		panic(fmt.Sprintf("sqlite ping error: %v", err))
+		return nil, fmt.Errorf("sqlite ping error: %w", err)
 	}
 
-	return db
+	return db, nil
 }
@@ -2,8 +2,10 @@ package manager
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
+	"strings"
 )
 
 //go:embed database/mysql/*
@@ -15,11 +17,36 @@ var databasePsql embed.FS
 //go:embed database/sqlite/*
 var databaseSqlite embed.FS
 
+//go:embed database/mongo/*
+var databaseMongo embed.FS
+
 // driverFS maps database type to its embedded FS
 var driverFS = map[string]embed.FS{
 	"sqlite":  databaseSqlite,
 	"pgxpool": databasePsql,
 	"mysql":   databaseMysql,
+	"mongo":   databaseMongo,
+}
+
+//go:embed openapi/*
+var packageOpenAPI embed.FS
+
+//go:embed cache
+var packageCache embed.FS
+
+//go:embed connect/*
+var packageConnect embed.FS
+
+// packageFS maps optional project packages to their embedded FS
+var packageFS = map[string]embed.FS{
+	"openapi": packageOpenAPI,
+	"cache":   packageCache,
+	"connect": packageConnect,
+}
+
+// cacheFS maps cache type to the embedded FS holding its client
+var cacheFS = map[string]embed.FS{
+	"redis": packageCache,
 }
 
 // Manager holds embedded database files
@@ -27,10 +54,13 @@ type Manager struct {
 	Database DatabaseFiles
 }
 
-// DatabaseFiles holds the key source files for a database driver
+// DatabaseFiles holds the key source files for a database or cache driver.
+// gorm and ent are only set for the SQL databases.
 type DatabaseFiles struct {
 	config []byte
 	init   []byte
+	gorm   []byte
+	ent    []byte
 }
 
 func (df *DatabaseFiles) GetConfig() []byte {
@@ -41,6 +71,16 @@ func (df *DatabaseFiles) GetInit() []byte {
 	return df.init
 }
 
+// GetGorm returns the source opening GORM on the client of GetInit
+func (df *DatabaseFiles) GetGorm() []byte {
+	return df.gorm
+}
+
+// GetEnt returns the source creating an ent driver on the client of GetInit
+func (df *DatabaseFiles) GetEnt() []byte {
+	return df.ent
+}
+
 // Manage loads and returns all embedded files for the given database type
 func Manage(database string) *Manager {
 	fsys, ok := driverFS[database]
@@ -48,8 +88,25 @@ func Manage(database string) *Manager {
 		panic(fmt.Errorf("unknown database driver: %s", database))
 	}
 
-	basePath := fmt.Sprintf("database/%s", database)
+	return &Manager{
+		Database: loadDriver(fsys, fmt.Sprintf("database/%s", database)),
+	}
+}
+
+// ManageCache loads the embedded client files for the given cache type
+func ManageCache(cache string) *DatabaseFiles {
+	fsys, ok := cacheFS[cache]
+	if !ok {
+		panic(fmt.Errorf("unknown cache driver: %s", cache))
+	}
 
+	files := loadDriver(fsys, fmt.Sprintf("cache/%s", cache))
+	return &files
+}
+
+// loadDriver reads the config.yaml and init.go of a driver from basePath, and
+// its gorm.go and ent.go when it has them
+func loadDriver(fsys fs.FS, basePath string) DatabaseFiles {
 	config, err := readFile(fsys, basePath+"/config.yaml")
 	if err != nil {
 		panic(fmt.Errorf("failed to load config.yaml: %w", err))
@@ -60,11 +117,70 @@ func Manage(database string) *Manager {
 		panic(fmt.Errorf("failed to load init.go: %w", err))
 	}
 
-	return &Manager{
-		Database: DatabaseFiles{
-			config: config,
-			init:   initCode,
-		},
+	files := DatabaseFiles{
+		config: config,
+		init:   initCode,
+	}
+	for name, dst := range map[string]*[]byte{"gorm.go": &files.gorm, "ent.go": &files.ent} {
+		content, err := readFile(fsys, basePath+"/"+name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			panic(fmt.Errorf("failed to load %s: %w", name, err))
+		}
+		*dst = content
+	}
+
+	return files
+}
+
+// PackageFiles holds the sources of an optional project package and the
+// config section it reads, if any
+type PackageFiles struct {
+	config []byte
+	files  map[string][]byte
+}
+
+func (pf *PackageFiles) GetConfig() []byte {
+	return pf.config
+}
+
+// GetFiles returns the Go files of the package by file name
+func (pf *PackageFiles) GetFiles() map[string][]byte {
+	return pf.files
+}
+
+// ManagePackage loads the embedded files of the given optional package
+func ManagePackage(name string) *PackageFiles {
+	fsys, ok := packageFS[name]
+	if !ok {
+		panic(fmt.Errorf("unknown package: %s", name))
+	}
+
+	config, err := readFile(fsys, name+"/config.yaml")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		panic(fmt.Errorf("failed to load config.yaml: %w", err))
+	}
+
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		panic(fmt.Errorf("can't read package %s: %w", name, err))
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		content, err := readFile(fsys, name+"/"+entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		files[entry.Name()] = content
+	}
+
+	return &PackageFiles{
+		config: config,
+		files:  files,
 	}
 }
 
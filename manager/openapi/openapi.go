@@ -0,0 +1,283 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	Enabled bool   `mapstructure:"enabled" yaml:"enabled"`
+	Title   string `mapstructure:"title" yaml:"title"`
+	Version string `mapstructure:"version" yaml:"version"`
+}
+
+// Spec builds an OpenAPI 3 document from the routes registered with Add.
+// Schemas are derived from the json tags of the registered types and every
+// response is wrapped in the {success, error, data} envelope of the handlers.
+type Spec struct {
+	config Config
+
+	mu      sync.Mutex
+	paths   map[string]map[string]any
+	schemas map[string]any
+}
+
+// New creates an empty document. When the config is disabled Add does nothing
+// and the handlers answer 404.
+func New(config Config) *Spec {
+	if config.Title == "" {
+		config.Title = "API"
+	}
+	if config.Version == "" {
+		config.Version = "0.1.0"
+	}
+
+	return &Spec{
+		config: config,
+		paths:  map[string]map[string]any{},
+		schemas: map[string]any{
+			"jsonResponse": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"success": map[string]any{"type": "boolean"},
+					"error":   map[string]any{"type": "string"},
+					"data":    map[string]any{},
+				},
+			},
+		},
+	}
+}
+
+// Enabled reports whether the document is served.
+func (s *Spec) Enabled() bool {
+	return s.config.Enabled
+}
+
+// Add documents a route like Add("POST", "/products/:id", domain.Product{}, domain.Product{}).
+// request is a value of the JSON body and response a value of the data passed
+// to successResponse; nil means there is none.
+func (s *Spec) Add(method, path string, request, response any) {
+	if !s.Enabled() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, params := pathParams(path)
+	operation := map[string]any{
+		"responses": map[string]any{
+			"200":     jsonContent("OK", s.envelope(response)),
+			"default": jsonContent("Error", ref("jsonResponse")),
+		},
+	}
+	if len(params) > 0 {
+		operation["parameters"] = params
+	}
+	if request != nil {
+		body := jsonContent("", s.schema(reflect.TypeOf(request)))
+		delete(body, "description")
+		body["required"] = true
+		operation["requestBody"] = body
+	}
+
+	if s.paths[path] == nil {
+		s.paths[path] = map[string]any{}
+	}
+	s.paths[path][strings.ToLower(method)] = operation
+}
+
+// Document returns the OpenAPI document.
+func (s *Spec) Document() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return map[string]any{
+		"openapi":    "3.0.3",
+		"info":       map[string]any{"title": s.config.Title, "version": s.config.Version},
+		"paths":      s.paths,
+		"components": map[string]any{"schemas": s.schemas},
+	}
+}
+
+// SpecHandler serves the document as JSON.
+func (s *Spec) SpecHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.Enabled() {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.Document())
+	})
+}
+
+// UIHandler serves a Swagger UI page loading the document from specURL.
+func (s *Spec) UIHandler(specURL string) http.Handler {
+	url, _ := json.Marshal(specURL)
+	page := fmt.Sprintf(swaggerUI, html.EscapeString(s.config.Title), url)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.Enabled() {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	})
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>%s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: %s, dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+// envelope wraps the schema of response in jsonResponse.
+func (s *Spec) envelope(response any) any {
+	if response == nil {
+		return ref("jsonResponse")
+	}
+
+	return map[string]any{
+		"allOf": []any{
+			ref("jsonResponse"),
+			map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"data": s.schema(reflect.TypeOf(response))},
+			},
+		},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema describes how encoding/json encodes t. Named structs are added to
+// the components and referenced.
+func (s *Spec) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, ok := s.schemas[t.Name()]; !ok {
+			// registered before the fields so recursive types end
+			s.schemas[t.Name()] = map[string]any{"type": "object"}
+			s.schemas[t.Name()] = s.structSchema(t)
+		}
+		return ref(t.Name())
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return s.structSchema(t)
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema describes the fields of a struct following the json tags.
+func (s *Spec) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded := s.structSchema(fieldType)
+			for key, value := range embedded["properties"].(map[string]any) {
+				properties[key] = value
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = s.schema(field.Type)
+	}
+
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+// pathParams converts a router path like /products/:id to /products/{id}
+// and returns its path parameters.
+func pathParams(path string) (string, []any) {
+	segments := strings.Split(path, "/")
+	var params []any
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			continue
+		}
+
+		name := strings.TrimSuffix(segment[1:], "?")
+		segments[i] = "{" + name + "}"
+		params = append(params, map[string]any{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		})
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(description string, schema any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": schema},
+		},
+	}
+}
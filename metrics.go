@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Names used when metrics are wired into a project.
+const (
+	metricsTemplatesDir  = "templates/metrics"
+	metricsFrameworksDir = "frameworks"
+	metricsCollectorsDir = "collectors"
+	metricsPackageDir    = "pkg/metrics"
+	metricsPath          = "/metrics"
+	adminPortKey         = "admin_port"
+	adminPort            = "6060"
+)
+
+// metricsStatement creates the metrics in app.Run.
+const metricsStatement = "metrics := metrics.New()"
+
+// bindMetrics adds Prometheus metrics to the project: pkg/metrics, a
+// RequestMetrics middleware recording the rate, errors and duration of every
+// request by route template, /metrics on the router, the pool stats of every
+// database and an admin server with net/http/pprof on app.admin_port, which
+// is left empty, and so off, in prod. Steps already applied are skipped.
+func bindMetrics(p *project, app domain.App) error {
+	dialect, err := utils.HandlerDialect(app.Framework)
+	if err != nil {
+		return err
+	}
+
+	// ───── Step 1: Write the package and the middleware ─────
+	frameworkDir := path.Join(metricsFrameworksDir, app.Framework.ToDirectory()) + "/"
+	err = fs.WalkDir(templatesFS, metricsTemplatesDir, func(source string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		// Collectors are written with the database they collect
+		rel := strings.TrimPrefix(source, metricsTemplatesDir+"/")
+		if strings.HasPrefix(rel, metricsCollectorsDir+"/") {
+			return nil
+		}
+
+		// Only the middleware of the framework is copied
+		if strings.HasPrefix(rel, metricsFrameworksDir+"/") {
+			if !strings.HasPrefix(rel, frameworkDir) {
+				return nil
+			}
+			rel = strings.TrimPrefix(rel, frameworkDir)
+		}
+		if p.exists(rel) {
+			return nil
+		}
+
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		return p.renderFile(rel, source, renderTemplate(app, rel, content))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the metrics files: %w", err)
+	}
+
+	// ───── Step 2: Add the admin port to the app section of env files ─────
+	appSection := regexp.MustCompile(`(?m)^app:\n(?:[ \t]+.*\n)*`)
+	configKey := regexp.MustCompile(`(?m)^[ \t]+` + adminPortKey + `:`)
+	for _, env := range app.Envs {
+		configPath := path.Join("etc", env+".yaml")
+
+		body, err := os.ReadFile(p.path(configPath))
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", configPath, err)
+		}
+		section := appSection.Find(body)
+		if section == nil || configKey.Match(section) {
+			continue
+		}
+
+		port := adminPort
+		if env == "prod" {
+			port = ""
+		}
+		config := fmt.Sprintf("%s  %s: %q\n", section, adminPortKey, port)
+		if err := p.replaceInFile(configPath, string(section), config); err != nil {
+			return fmt.Errorf("failed to add %s to %q: %w", adminPortKey, configPath, err)
+		}
+	}
+
+	// ───── Step 3: Add field to AppConfig struct ─────
+	appField := fmt.Sprintf("AdminPort string `mapstructure:\"%s\" yaml:\"%s\"`", adminPortKey, adminPortKey)
+	if err := p.appendFieldStruct(appStructFile, "AppConfig", appField); err != nil {
+		return fmt.Errorf("failed to append field to AppConfig struct: %w", err)
+	}
+
+	// ───── Step 4: Create the metrics and the admin server in the app ─────
+	pkgPath := path.Join(app.Module, metricsPackageDir)
+	if err := p.addImport(appRunFile, pkgPath); err != nil {
+		return fmt.Errorf("failed to add import to app/build.go: %w", err)
+	}
+
+	admin := `if appConfig.App.AdminPort != "" {
+	admin := metrics.AdminServer(":" + appConfig.App.AdminPort)
+	go func() {
+		if err := serve(ctx, admin.ListenAndServe, admin.Shutdown, appConfig.App.ShutdownTimeout); err != nil {
+			logger.Error("admin server stopped", "err", err)
+		}
+	}()
+}`
+	for _, stmt := range []string{metricsStatement, admin} {
+		if err := p.insertStatement(appRunFile, "Run", "repo := repo.NewRepo(", stmt); err != nil {
+			return fmt.Errorf("failed to create the metrics in app/build.go: %w", err)
+		}
+	}
+
+	// ───── Step 5: Collect the pool stats of the databases ─────
+	fields, err := utils.StructFields(p.path(appStructFile), "AppConfigs")
+	if err != nil {
+		return fmt.Errorf("failed to read AppConfigs struct: %w", err)
+	}
+	configTypes := map[string]bool{}
+	for _, typ := range fields {
+		configTypes[typ] = true
+	}
+	for _, dbType := range domain.SupportedDatabaseTypes {
+		if !configTypes[dbType.ToCoreDatabase()+".Config"] {
+			continue
+		}
+
+		_, repoField, err := databaseFields(p, dbType)
+		if err != nil {
+			return err
+		}
+		if err := bindPoolMetrics(p, app, dbType, repoField); err != nil {
+			return err
+		}
+	}
+
+	// ───── Step 6: Record the requests and serve the metrics ─────
+	statements := []string{
+		fmt.Sprintf("%s.Use(handler.RequestMetrics(metrics))", dialect.RouterVar),
+		dialect.Route(dialect.RouterVar, "GET", metricsPath, fmt.Sprintf(dialect.WrapHTTP, "metrics.Handler()")),
+	}
+	if dialect.WrapHTTPImport != "" {
+		if err := p.addImport(appRunFile, dialect.WrapHTTPImport); err != nil {
+			return fmt.Errorf("failed to add import to app/build.go: %w", err)
+		}
+	}
+
+	// The pattern of a ServeMux request is only set inside the mux, so the
+	// middleware wraps it in the server instead
+	if app.Framework == domain.FrameworkTypeStdlib {
+		statements = statements[1:]
+		wrapped := fmt.Sprintf("handler.RequestLogger(logger)(%s)", dialect.RouterVar)
+		metered := fmt.Sprintf("handler.RequestLogger(logger)(handler.RequestMetrics(metrics)(%s))", dialect.RouterVar)
+		if err := p.replaceInFile(appRunFile, wrapped, metered); err != nil {
+			return fmt.Errorf("failed to record the requests in app/build.go: %w", err)
+		}
+	}
+
+	for _, stmt := range statements {
+		if err := p.insertStatement(appRunFile, "Run", "handler.BindRoutes(", stmt); err != nil {
+			return fmt.Errorf("failed to serve the metrics in app/build.go: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// bindPoolMetrics registers the pool stats of the client name of dbType
+// with the metrics of app.Run. Mongo has no pool stats and projects without
+// metrics are left alone.
+func bindPoolMetrics(p *project, app domain.App, dbType domain.DbType, name string) error {
+	if !dbType.IsSQL() {
+		return nil
+	}
+
+	body, err := os.ReadFile(p.path(appRunFile))
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", appRunFile, err)
+	}
+	if !strings.Contains(string(body), metricsStatement) {
+		return nil
+	}
+
+	service := dbType.ShortName()
+	var stmt string
+	switch {
+	case app.DataAccess == domain.DataAccessGORM:
+		stmt = fmt.Sprintf("if sqlDB, err := %s.DB(); err == nil {\n\tmetrics.AddSQL(%q, sqlDB)\n}", name, service)
+	case app.DataAccess == domain.DataAccessEnt:
+		stmt = fmt.Sprintf("metrics.AddSQL(%q, %s.DB())", service, name)
+	case dbType == domain.DBTypePostgres:
+		rel := path.Join(metricsPackageDir, "pgxpool.go")
+		if !p.exists(rel) {
+			source := path.Join(metricsTemplatesDir, metricsCollectorsDir, "pgxpool.go")
+			content, err := templatesFS.ReadFile(source)
+			if err != nil {
+				return err
+			}
+			if err := p.renderFile(rel, source, content); err != nil {
+				return fmt.Errorf("failed to write file %q: %w", rel, err)
+			}
+		}
+		stmt = fmt.Sprintf("metrics.AddPgxPool(%q, %s)", service, name)
+	default:
+		stmt = fmt.Sprintf("metrics.AddSQL(%q, %s)", service, name)
+	}
+
+	if err := p.insertStatement(appRunFile, "Run", "repo := repo.NewRepo(", stmt); err != nil {
+		return fmt.Errorf("failed to collect the %s pool stats in app/build.go: %w", service, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+// Names used when the migrations are wired into a project.
+const (
+	migrateTemplatesDir = "templates/migrate"
+	migrateDriversDir   = "drivers"
+	migratePackageDir   = "pkg/migrate"
+	migrateCmdFile      = "cmd/migrate/main.go"
+	// migrateClientPackage is the placeholder package cmd/migrate opens the
+	// database with, replaced by the client package of the database.
+	migrateClientPackage = "pkg/store"
+	migrationsDir        = "migrations"
+	notesTemplatesDir    = "templates/notes"
+)
+
+// migrationFile matches the up file of a migration and captures its version.
+var migrationFile = regexp.MustCompile(`^(\d+)_\w+\.up\.sql$`)
+
+// bindMigrations adds the migrations of dbType to the project: the
+// migrations/ directory embedding the SQL files, pkg/migrate with the driver
+// of the database, cmd/migrate reading AppConfigs.DB from etc/<env>.yaml and
+// the migrate-* Makefile targets. Databases not queried with SQL are skipped,
+// as are steps already applied.
+func bindMigrations(p *project, app domain.App, dbType domain.DbType) error {
+	if !dbType.IsSQL() {
+		return nil
+	}
+	coreDB := dbType.ToCoreDatabase()
+
+	makeTargets, err := templatesFS.ReadFile(migrateTemplatesDir + "/" + makefile)
+	if err != nil {
+		return err
+	}
+
+	// ───── Step 1: Write the package, command and first migration ─────
+	err = fs.WalkDir(templatesFS, migrateTemplatesDir, func(source string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimPrefix(source, migrateTemplatesDir+"/")
+		if rel == makefile {
+			return nil
+		}
+
+		// Only the driver of the database is copied
+		if driver, ok := strings.CutPrefix(rel, migrateDriversDir+"/"); ok {
+			if driver != coreDB+".go" {
+				return nil
+			}
+			rel = path.Join(migratePackageDir, driver)
+		}
+		if p.exists(rel) {
+			return nil
+		}
+
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		content = renderTemplate(app, rel, content)
+
+		if rel == migrateCmdFile {
+			client := strings.Replace(string(content), path.Join(app.Module, migrateClientPackage), path.Join(app.Module, "pkg", coreDB), 1)
+			content = []byte(strings.Replace(client, path.Base(migrateClientPackage)+".NewClient(", coreDB+".NewClient(", 1))
+		}
+
+		return p.renderFile(rel, source, content)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the migration files: %w", err)
+	}
+
+	// ───── Step 2: Add the migrate targets to the Makefile ─────
+	if !p.exists(makefile) {
+		return nil
+	}
+
+	body, err := os.ReadFile(p.path(makefile))
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", makefile, err)
+	}
+	if regexp.MustCompile(`(?m)^migrate-up:`).Match(body) {
+		return nil
+	}
+	if err := p.appendToFile(makefile, makeTargets); err != nil {
+		return fmt.Errorf("failed to append targets to %q: %w", makefile, err)
+	}
+
+	return nil
+}
+
+// bindNotesMigration adds the migration creating the notes table the sqlc
+// and ent samples query, numbered after the existing migrations. It is
+// skipped when the project has it already.
+func bindNotesMigration(p *project, app domain.App, dbType domain.DbType) error {
+	version, err := nextMigrationVersion(p)
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join(notesTemplatesDir, dbType.ToCoreDatabase())
+	entries, err := templatesFS.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		matches, err := fs.Glob(os.DirFS(p.path(migrationsDir)), "*_"+entry.Name())
+		if err != nil {
+			return err
+		}
+		if len(matches) > 0 {
+			continue
+		}
+
+		source := path.Join(dir, entry.Name())
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+
+		rel := path.Join(migrationsDir, fmt.Sprintf("%06d_%s", version, entry.Name()))
+		if err := p.renderFile(rel, source, renderTemplate(app, rel, content)); err != nil {
+			return fmt.Errorf("failed to write the notes migration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// nextMigrationVersion returns the version following the latest migration of
+// the project.
+func nextMigrationVersion(p *project) (int, error) {
+	entries, err := os.ReadDir(p.path(migrationsDir))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	version := 0
+	for _, entry := range entries {
+		if match := migrationFile.FindStringSubmatch(entry.Name()); match != nil {
+			v, err := strconv.Atoi(match[1])
+			if err != nil {
+				return 0, fmt.Errorf("invalid migration version %q: %w", entry.Name(), err)
+			}
+			version = max(version, v)
+		}
+	}
+
+	return version + 1, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// projectPlan is the report of a dry run.
+type projectPlan struct {
+	Root          string        `json:"root"`
+	Files         []plannedFile `json:"files"`
+	Edits         []projectEdit `json:"edits"`
+	Commands      []string      `json:"commands"`
+	DockerCompose string        `json:"docker_compose,omitempty"`
+}
+
+// plannedFile is a file the project would contain.
+type plannedFile struct {
+	Path string `json:"path"`
+	// Template is the embedded template the file is rendered from, if any.
+	Template string `json:"template,omitempty"`
+	// Status is "copied" when the file equals its template, "rendered" when
+	// it was changed and "created" when it has no template.
+	Status string `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// buildPlan compares every file of the rendered project with its template.
+func buildPlan(p *project, app domain.App) (projectPlan, error) {
+	plan := projectPlan{
+		Root:     app.Name,
+		Edits:    p.edits,
+		Commands: p.commands,
+	}
+
+	err := filepath.WalkDir(p.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(p.dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		file := plannedFile{Path: rel, Template: p.sources[rel], Status: "created"}
+		newName := app.Name + "/" + rel
+
+		if file.Template == "" {
+			file.Diff = utils.UnifiedDiff("/dev/null", newName, "", string(content))
+		} else {
+			raw, err := templatesFS.ReadFile(file.Template)
+			if err != nil {
+				return err
+			}
+
+			file.Status = "copied"
+			if file.Diff = utils.UnifiedDiff(file.Template, newName, string(raw), string(content)); file.Diff != "" {
+				file.Status = "rendered"
+			}
+		}
+
+		if rel == "docker-compose.yaml" {
+			plan.DockerCompose = string(content)
+		}
+
+		plan.Files = append(plan.Files, file)
+		return nil
+	})
+
+	return plan, err
+}
+
+// printPlan writes the dry run report of the project as text or JSON.
+func printPlan(w io.Writer, p *project, app domain.App, asJSON bool) error {
+	plan, err := buildPlan(p, app)
+	if err != nil {
+		return fmt.Errorf("error building dry run plan: %w", err)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+
+	fmt.Fprintf(w, "Dry run: nothing was written.\n\n")
+
+	paths := make([]string, 0, len(plan.Files))
+	for _, file := range plan.Files {
+		paths = append(paths, file.Path)
+	}
+	fmt.Fprint(w, renderTree(plan.Root, paths))
+
+	fmt.Fprintf(w, "\nEdits:\n")
+	for _, edit := range plan.Edits {
+		fmt.Fprintf(w, "  %s: %s %s\n", edit.File, edit.Action, strings.ReplaceAll(edit.Detail, "\n", "\\n"))
+	}
+
+	fmt.Fprintf(w, "\nCommands:\n")
+	for _, command := range plan.Commands {
+		fmt.Fprintf(w, "  %s\n", command)
+	}
+
+	if plan.DockerCompose != "" {
+		fmt.Fprintf(w, "\ndocker-compose.yaml:\n%s\n", plan.DockerCompose)
+	}
+
+	fmt.Fprintf(w, "\nDiffs against the templates:\n")
+	for _, file := range plan.Files {
+		if file.Diff != "" {
+			fmt.Fprintf(w, "\n%s", file.Diff)
+		}
+	}
+
+	return nil
+}
+
+// renderTree draws slash separated paths as a directory tree below root.
+func renderTree(root string, paths []string) string {
+	type node struct {
+		children map[string]*node
+	}
+	newNode := func() *node { return &node{children: map[string]*node{}} }
+
+	tree := newNode()
+	for _, p := range paths {
+		current := tree
+		for _, part := range strings.Split(p, "/") {
+			next, ok := current.children[part]
+			if !ok {
+				next = newNode()
+				current.children[part] = next
+			}
+			current = next
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(root + "/\n")
+
+	var walk func(n *node, prefix string)
+	walk = func(n *node, prefix string) {
+		names := make([]string, 0, len(n.children))
+		for name := range n.children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for i, name := range names {
+			child := n.children[name]
+			branch, indent := "├── ", "│   "
+			if i == len(names)-1 {
+				branch, indent = "└── ", "    "
+			}
+
+			if len(child.children) > 0 {
+				name += "/"
+			}
+			b.WriteString(prefix + branch + name + "\n")
+			walk(child, prefix+indent)
+		}
+	}
+	walk(tree, "")
+
+	return b.String()
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// project is a generated project on disk. Its methods apply the file and
+// AST edits from utils relative to the project root and record them, so a
+// dry run can report what generation does.
+type project struct {
+	dir string
+	// dryRun skips external commands; they are only recorded.
+	dryRun bool
+
+	// sources maps project files to the template they were rendered from.
+	sources  map[string]string
+	edits    []projectEdit
+	commands []string
+}
+
+// projectEdit describes a single change applied to a project file.
+type projectEdit struct {
+	File   string `json:"file"`
+	Action string `json:"action"`
+	Detail string `json:"detail"`
+}
+
+func newProject(dir string) *project {
+	return &project{
+		dir:     dir,
+		sources: map[string]string{},
+	}
+}
+
+// path returns the location of a slash separated project file on disk.
+func (p *project) path(rel string) string {
+	return filepath.Join(p.dir, filepath.FromSlash(rel))
+}
+
+// exists reports whether a project file exists.
+func (p *project) exists(rel string) bool {
+	_, err := os.Stat(p.path(rel))
+	return err == nil
+}
+
+func (p *project) record(file, action, detail string) {
+	p.edits = append(p.edits, projectEdit{File: file, Action: action, Detail: detail})
+}
+
+// renderFile writes a file rendered from the template at source.
+func (p *project) renderFile(rel, source string, content []byte) error {
+	p.sources[rel] = source
+	return writeProjectFile(p.dir, rel, content)
+}
+
+// writeFile writes a file that has no template.
+func (p *project) writeFile(rel string, content []byte) error {
+	p.record(rel, "WriteFile", fmt.Sprintf("%d bytes", len(content)))
+	return writeProjectFile(p.dir, rel, content)
+}
+
+func (p *project) appendToFile(rel string, data []byte) error {
+	p.record(rel, "AppendToFile", strings.TrimSpace(string(data)))
+	return utils.AppendToFile(p.path(rel), data)
+}
+
+func (p *project) appendFieldStruct(rel, structName, field string) error {
+	p.record(rel, "AppendFieldStruct", structName+": "+field)
+	return utils.AppendFieldStruct(p.path(rel), structName, field)
+}
+
+func (p *project) addImport(rel, importPath string) error {
+	p.record(rel, "AddImportToFile", importPath)
+	return utils.AddImportToFile(p.path(rel), importPath)
+}
+
+func (p *project) appendFuncArgument(rel, funcName, argName, argType string) error {
+	p.record(rel, "AppendFuncArgument", fmt.Sprintf("%s(%s %s)", funcName, argName, argType))
+	return utils.AppendFuncArgument(p.path(rel), funcName, argName, argType)
+}
+
+func (p *project) addReturnField(rel, funcName, fieldName string) error {
+	p.record(rel, "AddReturnFieldToConstructor", fmt.Sprintf("%s: %s: %s", funcName, fieldName, fieldName))
+	return utils.AddReturnFieldToConstructor(p.path(rel), funcName, fieldName)
+}
+
+func (p *project) replaceStructField(rel, structName, fieldName, field string) error {
+	p.record(rel, "ReplaceStructField", fmt.Sprintf("%s: %s => %s", structName, fieldName, field))
+	return utils.ReplaceStructField(p.path(rel), structName, fieldName, field)
+}
+
+func (p *project) replaceReturnField(rel, funcName, fieldName, key, value string) error {
+	p.record(rel, "ReplaceReturnField", fmt.Sprintf("%s: %s => %s: %s", funcName, fieldName, key, value))
+	return utils.ReplaceReturnField(p.path(rel), funcName, fieldName, key, value)
+}
+
+func (p *project) addCallArgument(rel, fullFuncName, arg string) error {
+	p.record(rel, "AddArgumentToFunctionCall", fmt.Sprintf("%s(%s)", fullFuncName, arg))
+	return utils.AddArgumentToFunctionCall(p.path(rel), fullFuncName, arg)
+}
+
+func (p *project) appendInterfaceMethod(rel, interfaceName, method string) error {
+	p.record(rel, "AppendInterfaceMethod", fmt.Sprintf("%s.%s", interfaceName, method))
+	return utils.AppendInterfaceMethod(p.path(rel), interfaceName, method)
+}
+
+func (p *project) appendStatement(rel, funcName, stmt string) error {
+	p.record(rel, "AppendStatementToFunc", fmt.Sprintf("%s: %s", funcName, stmt))
+	return utils.AppendStatementToFunc(p.path(rel), funcName, stmt)
+}
+
+func (p *project) insertStatement(rel, funcName, before, stmt string) error {
+	p.record(rel, "InsertStatementBefore", fmt.Sprintf("%s: %s before %s", funcName, stmt, before))
+	return utils.InsertStatementBefore(p.path(rel), funcName, before, stmt)
+}
+
+// replaceInFile replaces the first occurrence of placeholder in a project file.
+func (p *project) replaceInFile(rel, placeholder, value string) error {
+	p.record(rel, "Replace", fmt.Sprintf("%s => %q", placeholder, strings.TrimSpace(value)))
+
+	body, err := os.ReadFile(p.path(rel))
+	if err != nil {
+		return err
+	}
+
+	content := strings.Replace(string(body), placeholder, value, 1)
+	return os.WriteFile(p.path(rel), []byte(content), 0o644)
+}
+
+// updatePackageNameOnGCI sets the module path in the project .golangci.yml.
+func (p *project) updatePackageNameOnGCI(module string) error {
+	p.record(".golangci.yml", "UpdatePackageNameOnGCI", module)
+	return utils.UpdatePackageNameOnGCI(p.dir, module)
+}
+
+// logf prints a progress message unless this is a dry run.
+func (p *project) logf(format string, args ...any) {
+	if !p.dryRun {
+		fmt.Printf(format, args...)
+	}
+}
+
+// run executes a command in the project directory and prints its output.
+// In a dry run the command is only recorded.
+func (p *project) run(name string, args ...string) error {
+	p.commands = append(p.commands, strings.Join(append([]string{name}, args...), " "))
+	if p.dryRun {
+		return nil
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = p.dir
+
+	output, err := cmd.CombinedOutput()
+	p.logf("▶️ Running: %s %v\n%s\n", name, args, output)
+	if err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+
+	return nil
+}
+
+// openProject loads the settings of an existing project in dir from its
+// go.mod and etc directory.
+func openProject(dir string) (*project, domain.App, error) {
+	p := newProject(dir)
+	app := domain.App{Name: filepath.Base(filepath.Clean(dir))}
+
+	goMod, err := os.ReadFile(p.path("go.mod"))
+	if err != nil {
+		return nil, app, fmt.Errorf("%q is not a Go module: %w", dir, err)
+	}
+
+	modFile, err := modfile.ParseLax(p.path("go.mod"), goMod, nil)
+	if err != nil || modFile.Module == nil {
+		return nil, app, fmt.Errorf("can't find the module path in %s", p.path("go.mod"))
+	}
+	app.Module = modFile.Module.Mod.Path
+	var router string
+	if params, err := utils.FuncParamTypes(p.path(routesFile), "BindRoutes"); err == nil && len(params) > 0 {
+		router = params[0]
+	}
+	app.Framework = detectFramework(modFile, router)
+	app.DataAccess = detectDataAccess(p)
+
+	for _, file := range []string{appStructFile, repoFile, appRunFile} {
+		if !p.exists(file) {
+			return nil, app, fmt.Errorf("%q doesn't look like a goarm project: %s is missing", dir, file)
+		}
+	}
+
+	envFiles, err := filepath.Glob(p.path("etc/*.yaml"))
+	if err != nil {
+		return nil, app, err
+	}
+	sort.Strings(envFiles)
+	for _, file := range envFiles {
+		app.Envs = append(app.Envs, strings.TrimSuffix(filepath.Base(file), ".yaml"))
+	}
+
+	return p, app, nil
+}
+
+// detectDataAccess returns the ORM a Repo field holds a client of, or the raw
+// driver if there is none.
+func detectDataAccess(p *project) domain.DataAccess {
+	fields, err := utils.StructFields(p.path(repoFile), "Repo")
+	if err != nil {
+		return domain.DataAccessRaw
+	}
+
+	for _, style := range domain.SupportedDataAccess {
+		if style == domain.DataAccessRaw {
+			continue
+		}
+		for _, typ := range fields {
+			if typ == "*"+domain.DBTypePostgres.RepoVal(style) {
+				return style
+			}
+		}
+	}
+
+	return domain.DataAccessRaw
+}
+
+// detectFramework returns the framework a project requires in its go.mod,
+// or an empty FrameworkType if it requires none of the supported ones.
+// Standard library routers aren't required, they are recognised by router,
+// the type BindRoutes registers routes on.
+func detectFramework(modFile *modfile.File, router string) domain.FrameworkType {
+	for _, framework := range domain.SupportedFrameworkTypes {
+		dialect, err := utils.HandlerDialect(framework)
+		if err != nil {
+			continue
+		}
+
+		if !strings.Contains(dialect.Import, ".") {
+			if router == dialect.Router {
+				return framework
+			}
+			continue
+		}
+
+		for _, req := range modFile.Require {
+			if req.Mod.Path == dialect.Import {
+				return framework
+			}
+		}
+	}
+
+	return ""
+}
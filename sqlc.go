@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+	"github.com/MH-KodaCore/goarm/utils"
+)
+
+// Names used when sqlc is wired into a project.
+const (
+	sqlcTemplatesDir = "templates/sqlc"
+	sqlcEnginesDir   = "engines"
+	sqlcConfigFile   = "sqlc.yaml"
+	sqlcPackage      = "internal/repo/db"
+)
+
+// bindSQLC adds sqlc for dbType, the database of AppConfigs.DB: a sqlc.yaml
+// for its engine reading the schema from migrations/, the notes migration
+// with a sample query and its generated code in internal/repo/db, and the
+// sqlc Makefile targets. Repo embeds the generated Queries instead of the
+// client field of the database. Steps already applied are skipped.
+func bindSQLC(p *project, app domain.App, dbType domain.DbType) error {
+	if dbType.SqlcEngine() == "" {
+		return fmt.Errorf("sqlc needs an SQL database, %s isn't one", dbType)
+	}
+	coreDB := dbType.ToCoreDatabase()
+
+	_, repoField, err := databaseFields(p, dbType)
+	if err != nil {
+		return err
+	}
+	fields, err := utils.StructFields(p.path(repoFile), "Repo")
+	if err != nil {
+		return fmt.Errorf("failed to read Repo struct: %w", err)
+	}
+	// The client field is gone once the queries are embedded
+	client, embed := fields[repoField]
+	if embed && client != "*"+dbType.PackageVal() {
+		return fmt.Errorf("sqlc queries the driver client, Repo.%s is a %s", repoField, client)
+	}
+
+	// sqlc reads the schema from the migrations
+	if err := bindMigrations(p, app, dbType); err != nil {
+		return err
+	}
+	if err := bindNotesMigration(p, app, dbType); err != nil {
+		return err
+	}
+
+	// ───── Step 1: Write the config, sample and generated files ─────
+	engineDir := path.Join(sqlcEnginesDir, coreDB) + "/"
+	err = fs.WalkDir(templatesFS, sqlcTemplatesDir, func(source string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimPrefix(source, sqlcTemplatesDir+"/")
+		if rel == makefile {
+			return nil
+		}
+
+		// Only the files of the engine are copied
+		if strings.HasPrefix(rel, sqlcEnginesDir+"/") {
+			if !strings.HasPrefix(rel, engineDir) {
+				return nil
+			}
+			rel = strings.TrimPrefix(rel, engineDir)
+		}
+		if p.exists(rel) {
+			return nil
+		}
+
+		content, err := templatesFS.ReadFile(source)
+		if err != nil {
+			return err
+		}
+		content = renderTemplate(app, rel, content)
+
+		if rel == sqlcConfigFile {
+			config := strings.Replace(string(content), "@engine", dbType.SqlcEngine(), 1)
+			content = []byte(strings.Replace(config, "@sql_package", dbType.SqlcPackage(), 1))
+		}
+
+		return p.renderFile(rel, source, content)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the sqlc files: %w", err)
+	}
+
+	// ───── Step 2: Add the codegen targets to the Makefile ─────
+	if err := appendMakeTargets(p, sqlcTemplatesDir, "sqlc"); err != nil {
+		return err
+	}
+
+	// ───── Step 3: Embed the queries in Repo ─────
+	if !embed {
+		return nil
+	}
+
+	if err := p.addImport(repoFile, path.Join(app.Module, sqlcPackage)); err != nil {
+		return fmt.Errorf("failed to add import to repo/build.go: %w", err)
+	}
+	if err := p.replaceStructField(repoFile, "Repo", repoField, "*db.Queries"); err != nil {
+		return fmt.Errorf("failed to embed the queries in Repo: %w", err)
+	}
+	if err := p.replaceReturnField(repoFile, "NewRepo", repoField, "Queries", "newQueries("+repoField+")"); err != nil {
+		return fmt.Errorf("failed to create the queries in NewRepo: %w", err)
+	}
+
+	return nil
+}
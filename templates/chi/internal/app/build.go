@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"templates/internal/domain"
+	"templates/internal/handler"
+	"templates/internal/repo"
+	"templates/internal/service"
+	"templates/pkg/health"
+)
+
+func Run(ctx context.Context, appConfig domain.AppConfigs, logger *slog.Logger) error {
+	health := health.New()
+	repo := repo.NewRepo(logger)
+	service := service.NewService(repo, logger)
+
+	router := chi.NewRouter()
+	router.Use(handler.RequestLogger(logger))
+	handler.BindRoutes(router, handler.NewHandler(service, logger, health))
+
+	server := &http.Server{
+		Addr:              ":" + appConfig.App.Port,
+		Handler:           router,
+		ReadHeaderTimeout: appConfig.App.ReadHeaderTimeout,
+		ReadTimeout:       appConfig.App.ReadTimeout,
+		WriteTimeout:      appConfig.App.WriteTimeout,
+		IdleTimeout:       appConfig.App.IdleTimeout,
+	}
+
+	shutdown := health.Drain(server.Shutdown, appConfig.App.DrainDelay)
+
+	return serve(ctx, server.ListenAndServe, shutdown, appConfig.App.ShutdownTimeout)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"templates/pkg/logger"
+)
+
+type AppConfigs struct {
+	App AppConfig     `mapstructure:"app" yaml:"app"`
+	Log logger.Config `mapstructure:"log" yaml:"log"`
+}
+
+type AppConfig struct {
+	Host              string        `mapstructure:"host" yaml:"host"`
+	Port              string        `mapstructure:"port" yaml:"port"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout" yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `mapstructure:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout" yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout"`
+	DrainDelay        time.Duration `mapstructure:"drain_delay" yaml:"drain_delay"`
+}
@@ -0,0 +1,3 @@
+package handler
+
+type ServiceInterface interface{}
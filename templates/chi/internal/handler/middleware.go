@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"templates/pkg/logger"
+)
+
+// RequestLogger logs every request with its method, path, status, latency
+// and request id. The id is read from the X-Request-ID header, or generated,
+// and sent back in the response.
+func RequestLogger(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			requestID := logger.RequestID(r.Header.Get(logger.RequestIDHeader))
+			w.Header().Set(logger.RequestIDHeader, requestID)
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			logger.Request(r.Context(), log, r.Method, r.URL.Path, recorder.status, time.Since(start), requestID)
+		})
+	}
+}
+
+// statusRecorder remembers the status a handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the flusher and hijacker of the
+// underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
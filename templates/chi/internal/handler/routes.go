@@ -0,0 +1,9 @@
+package handler
+
+import "github.com/go-chi/chi/v5"
+
+func BindRoutes(router *chi.Mux, h *Handler) {
+	router.Get("/ping", h.Pong)
+	router.Get("/healthz", h.Healthz)
+	router.Get("/readyz", h.Readyz)
+}
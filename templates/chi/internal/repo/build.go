@@ -0,0 +1,13 @@
+package repo
+
+import "log/slog"
+
+type Repo struct {
+	logger *slog.Logger
+}
+
+func NewRepo(logger *slog.Logger) *Repo {
+	return &Repo{
+		logger: logger,
+	}
+}
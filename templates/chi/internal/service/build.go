@@ -0,0 +1,15 @@
+package service
+
+import "log/slog"
+
+type Service struct {
+	repo   RepoInterface
+	logger *slog.Logger
+}
+
+func NewService(repo RepoInterface, logger *slog.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+	}
+}
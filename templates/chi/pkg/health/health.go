@@ -0,0 +1,138 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout bounds every check of Check.
+const checkTimeout = 2 * time.Second
+
+// Statuses of a Result.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker pings a dependency, e.g. the Ping method of a database client.
+type Checker func(ctx context.Context) error
+
+// Result is the outcome of the check of a dependency.
+type Result struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report tells whether the app is ready to serve requests and why.
+type Report struct {
+	Ready        bool     `json:"ready"`
+	ShuttingDown bool     `json:"shutting_down"`
+	Checks       []Result `json:"checks"`
+}
+
+// Health checks the dependencies of the app and tracks its shutdown.
+type Health struct {
+	mu       sync.RWMutex
+	names    []string
+	checkers map[string]Checker
+
+	shuttingDown atomic.Bool
+}
+
+func New() *Health {
+	return &Health{
+		checkers: map[string]Checker{},
+	}
+}
+
+// Add registers the checker of a dependency. Adding a name again replaces
+// its checker.
+func (h *Health) Add(name string, check Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.checkers[name]; !ok {
+		h.names = append(h.names, name)
+	}
+	h.checkers[name] = check
+}
+
+// Check runs every checker concurrently. The app isn't ready when one of
+// them fails or once it shuts down.
+func (h *Health) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	names := append([]string(nil), h.names...)
+	checkers := make([]Checker, len(names))
+	for i, name := range names {
+		checkers[i] = h.checkers[name]
+	}
+	h.mu.RUnlock()
+
+	results := make([]Result, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check(ctx, names[i], checkers[i])
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		ShuttingDown: h.shuttingDown.Load(),
+		Checks:       results,
+	}
+	report.Ready = !report.ShuttingDown
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Ready = false
+		}
+	}
+
+	return report
+}
+
+func check(ctx context.Context, name string, checker Checker) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker(ctx)
+	result := Result{
+		Name:    name,
+		Status:  StatusUp,
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// Drain wraps the shutdown of a server: the app reports not ready as soon
+// as shutdown starts and keeps serving for delay, so load balancers stop
+// sending requests before the server stops accepting them.
+func (h *Health) Drain(shutdown func(context.Context) error, delay time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		h.shuttingDown.Store(true)
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+			}
+		}
+
+		return shutdown(ctx)
+	}
+}
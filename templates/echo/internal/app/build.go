@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+
+	"templates/internal/domain"
+	"templates/internal/handler"
+	"templates/internal/repo"
+	"templates/internal/service"
+	"templates/pkg/health"
+)
+
+func Run(ctx context.Context, appConfig domain.AppConfigs, logger *slog.Logger) error {
+	health := health.New()
+	repo := repo.NewRepo(logger)
+	service := service.NewService(repo, logger)
+
+	app := echo.New()
+	app.Use(handler.RequestLogger(logger))
+	handler.BindRoutes(app, handler.NewHandler(service, logger, health))
+
+	start := func() error { return app.Start(":" + appConfig.App.Port) }
+	shutdown := health.Drain(app.Shutdown, appConfig.App.DrainDelay)
+
+	return serve(ctx, start, shutdown, appConfig.App.ShutdownTimeout)
+}
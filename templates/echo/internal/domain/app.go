@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"templates/pkg/logger"
+)
+
+type AppConfigs struct {
+	App AppConfig     `mapstructure:"app" yaml:"app"`
+	Log logger.Config `mapstructure:"log" yaml:"log"`
+}
+
+type AppConfig struct {
+	Host            string        `mapstructure:"host" yaml:"host"`
+	Port            string        `mapstructure:"port" yaml:"port"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout"`
+	DrainDelay      time.Duration `mapstructure:"drain_delay" yaml:"drain_delay"`
+}
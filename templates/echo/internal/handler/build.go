@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+
+	"templates/pkg/health"
+	"templates/pkg/http"
+)
+
+type Handler struct {
+	service ServiceInterface
+	logger  *slog.Logger
+	health  *health.Health
+}
+
+func NewHandler(service ServiceInterface, logger *slog.Logger, health *health.Health) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+		health:  health,
+	}
+}
+
+type jsonResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Data    any    `json:"data"`
+}
+
+func response(ctx echo.Context, statusCode int, data any) error {
+	return ctx.JSON(statusCode, jsonResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+func successResponse(ctx echo.Context, data any) error {
+	return response(ctx, http.StatusOK, data)
+}
+
+func errResponse(ctx echo.Context, statusCode int, error error) error {
+	return ctx.JSON(statusCode, jsonResponse{
+		Success: false,
+		Error:   error.Error(),
+	})
+}
+
+func errBadResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusBadRequest, err)
+}
+
+func errUnauthorizedResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusUnauthorized, err)
+}
+
+func errForbiddenResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusForbidden, err)
+}
+
+func errNotFoundResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusNotFound, err)
+}
+
+func errConflictResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusConflict, err)
+}
+
+func errTooManyRequestsResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusTooManyRequests, err)
+}
+
+func errInternalServerErrorResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusInternalServerError, err)
+}
+
+func errNotImplementedResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusNotImplemented, err)
+}
+
+func errServiceUnavailableResponse(ctx echo.Context, err error) error {
+	return errResponse(ctx, http.StatusServiceUnavailable, err)
+}
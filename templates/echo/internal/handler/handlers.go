@@ -0,0 +1,9 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) Pong(ctx echo.Context) error {
+	return successResponse(ctx, "pong")
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"templates/pkg/logger"
+)
+
+// RequestLogger logs every request with its method, path, status, latency
+// and request id. The id is read from the X-Request-ID header, or generated,
+// and sent back in the response.
+func RequestLogger(log *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+			requestID := logger.RequestID(ctx.Request().Header.Get(logger.RequestIDHeader))
+			ctx.Response().Header().Set(logger.RequestIDHeader, requestID)
+
+			// Errors are written here so their status is logged
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			request := ctx.Request()
+			logger.Request(request.Context(), log, request.Method, request.URL.Path, ctx.Response().Status, time.Since(start), requestID)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+func BindRoutes(app *echo.Echo, h *Handler) {
+	app.GET("/ping", h.Pong)
+	app.GET("/healthz", h.Healthz)
+	app.GET("/readyz", h.Readyz)
+}
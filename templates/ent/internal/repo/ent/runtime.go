@@ -0,0 +1,20 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"templates/internal/repo/ent/note"
+	"templates/internal/repo/ent/schema"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	noteFields := schema.Note{}.Fields()
+	_ = noteFields
+	// noteDescTitle is the schema descriptor for title field.
+	noteDescTitle := noteFields[0].Descriptor()
+	// note.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	note.TitleValidator = noteDescTitle.Validators[0].(func(string) error)
+}
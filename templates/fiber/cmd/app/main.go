@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
 	"path"
+	"syscall"
 
 	"templates/internal/app"
 	"templates/internal/domain"
 	"templates/pkg/config/viper"
+	"templates/pkg/logger"
 )
 
 func main() {
@@ -21,7 +27,21 @@ func main() {
 		panic(fmt.Sprintf("error parse configs error:%+v", err))
 	}
 
-	if err := app.Run(appConfig); err != nil {
-		fmt.Printf("can't run app %+v", err)
+	logger, err := logger.New(appConfig.Log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "can't create logger: %v\n", err)
+		os.Exit(1)
+	}
+	// The gRPC interceptors and the log package write through it too
+	slog.SetDefault(logger)
+
+	// Cancelled on SIGINT or SIGTERM, which shuts the app down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err = app.Run(ctx, appConfig, logger)
+	stop()
+
+	if err != nil {
+		logger.Error("can't run app", "err", err)
+		os.Exit(1)
 	}
 }
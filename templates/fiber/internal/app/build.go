@@ -1,20 +1,29 @@
 package app
 
 import (
+	"context"
+	"log/slog"
+
 	"github.com/gofiber/fiber/v2"
 
 	"templates/internal/domain"
 	"templates/internal/handler"
 	"templates/internal/repo"
 	"templates/internal/service"
+	"templates/pkg/health"
 )
 
-func Run(appConfig domain.AppConfigs) error {
-	repo := repo.NewRepo()
-	service := service.NewService(repo)
+func Run(ctx context.Context, appConfig domain.AppConfigs, logger *slog.Logger) error {
+	health := health.New()
+	repo := repo.NewRepo(logger)
+	service := service.NewService(repo, logger)
 
 	app := fiber.New()
-	handler.BindRoutes(app, handler.NewHandler(service))
+	app.Use(handler.RequestLogger(logger))
+	handler.BindRoutes(app, handler.NewHandler(service, logger, health))
+
+	listen := func() error { return app.Listen(":" + appConfig.App.Port) }
+	shutdown := health.Drain(app.ShutdownWithContext, appConfig.App.DrainDelay)
 
-	return app.Listen(":" + appConfig.App.Port)
+	return serve(ctx, listen, shutdown, appConfig.App.ShutdownTimeout)
 }
@@ -1,18 +1,25 @@
 package handler
 
 import (
+	"log/slog"
+
 	"github.com/gofiber/fiber/v2"
 
+	"templates/pkg/health"
 	"templates/pkg/http"
 )
 
 type Handler struct {
 	service ServiceInterface
+	logger  *slog.Logger
+	health  *health.Health
 }
 
-func NewHandler(service ServiceInterface) *Handler {
+func NewHandler(service ServiceInterface, logger *slog.Logger, health *health.Health) *Handler {
 	return &Handler{
 		service: service,
+		logger:  logger,
+		health:  health,
 	}
 }
 
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"templates/pkg/http"
+)
+
+var errNotReady = errors.New("not ready")
+
+// Healthz tells the app is alive, whatever the state of its dependencies.
+func (h *Handler) Healthz(ctx *fiber.Ctx) error {
+	return successResponse(ctx, "ok")
+}
+
+// Readyz pings every dependency of the app and answers with their status
+// and latency, with a 503 when one of them fails or the app shuts down.
+func (h *Handler) Readyz(ctx *fiber.Ctx) error {
+	report := h.health.Check(ctx.UserContext())
+	if !report.Ready {
+		return ctx.Status(http.StatusServiceUnavailable).JSON(jsonResponse{
+			Success: false,
+			Error:   errNotReady.Error(),
+			Data:    report,
+		})
+	}
+
+	return successResponse(ctx, report)
+}
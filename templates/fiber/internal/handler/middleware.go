@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"templates/pkg/logger"
+)
+
+// RequestLogger logs every request with its method, path, status, latency
+// and request id. The id is read from the X-Request-ID header, or generated,
+// and sent back in the response.
+func RequestLogger(log *slog.Logger) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+		requestID := logger.RequestID(ctx.Get(logger.RequestIDHeader))
+		ctx.Set(logger.RequestIDHeader, requestID)
+
+		err := ctx.Next()
+
+		// The error handler writes the status of returned errors later
+		status := ctx.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		logger.Request(ctx.UserContext(), log, ctx.Method(), ctx.Path(), status, time.Since(start), requestID)
+		return err
+	}
+}
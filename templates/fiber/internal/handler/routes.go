@@ -4,4 +4,6 @@ import "github.com/gofiber/fiber/v2"
 
 func BindRoutes(app *fiber.App, h *Handler) {
 	app.Get("/ping", h.Pong)
+	app.Get("/healthz", h.Healthz)
+	app.Get("/readyz", h.Readyz)
 }
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		checks    map[string]error
+		wantReady bool
+	}{
+		{name: "no checks", wantReady: true},
+		{name: "every check passes", checks: map[string]error{"db": nil, "cache": nil}, wantReady: true},
+		{name: "a check fails", checks: map[string]error{"db": nil, "cache": errors.New("connection refused")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New()
+			for name, err := range tt.checks {
+				h.Add(name, func(context.Context) error { return err })
+			}
+
+			report := h.Check(context.Background())
+			if report.Ready != tt.wantReady || report.ShuttingDown {
+				t.Fatalf("unexpected report: %+v", report)
+			}
+			if len(report.Checks) != len(tt.checks) {
+				t.Fatalf("unexpected checks: %+v", report.Checks)
+			}
+			for _, result := range report.Checks {
+				want := StatusUp
+				if tt.checks[result.Name] != nil {
+					want = StatusDown
+				}
+				if result.Status != want {
+					t.Fatalf("unexpected status of %s: %+v", result.Name, result)
+				}
+			}
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Run("not ready before the delay, shut down after it", func(t *testing.T) {
+		h := New()
+		h.Add("db", func(context.Context) error { return nil })
+
+		shutdownAt := make(chan time.Time, 1)
+		drain := h.Drain(func(context.Context) error {
+			shutdownAt <- time.Now()
+			return nil
+		}, 200*time.Millisecond)
+
+		start := time.Now()
+		done := make(chan error, 1)
+		go func() { done <- drain(context.Background()) }()
+
+		for !h.Check(context.Background()).ShuttingDown {
+			if time.Since(start) > time.Second {
+				t.Fatal("expected the app to report shutting down")
+			}
+			time.Sleep(time.Millisecond)
+		}
+		if report := h.Check(context.Background()); report.Ready {
+			t.Fatalf("expected the app not to be ready while draining: %+v", report)
+		}
+		select {
+		case <-shutdownAt:
+			t.Fatal("expected shutdown to wait for the delay")
+		default:
+		}
+
+		if err := <-done; err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if elapsed := (<-shutdownAt).Sub(start); elapsed < 200*time.Millisecond {
+			t.Fatalf("shutdown ran after %s, before the delay", elapsed)
+		}
+	})
+
+	t.Run("a canceled context cuts the delay", func(t *testing.T) {
+		h := New()
+		wantErr := errors.New("shutdown failed")
+		drain := h.Drain(func(context.Context) error { return wantErr }, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := drain(ctx); !errors.Is(err, wantErr) {
+			t.Fatalf("expected the error of shutdown, got %v", err)
+		}
+	})
+}
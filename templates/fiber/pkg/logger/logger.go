@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader carries the id of a request, see RequestID.
+const RequestIDHeader = "X-Request-ID"
+
+type Config struct {
+	// Level is debug, info (default), warn or error.
+	Level string `mapstructure:"level" yaml:"level"`
+	// Format is text (default) or json.
+	Format string `mapstructure:"format" yaml:"format"`
+}
+
+// New creates a logger writing to stdout with the level and format of cfg.
+func New(cfg Config) (*slog.Logger, error) {
+	var level slog.Level
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+	}
+	options := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(os.Stdout, options)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stdout, options)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q (supported: text, json)", cfg.Format)
+	}
+}
+
+// RequestID returns id, the X-Request-ID sent by the client or a proxy, or a
+// new random id when it is empty or too long to be one.
+func RequestID(id string) string {
+	if id != "" && len(id) <= 128 {
+		return id
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Request logs a served request, at warn level for 4xx and error level for
+// 5xx statuses.
+func Request(ctx context.Context, log *slog.Logger, method, path string, status int, latency time.Duration, requestID string) {
+	level := slog.LevelInfo
+	switch {
+	case status >= 500:
+		level = slog.LevelError
+	case status >= 400:
+		level = slog.LevelWarn
+	}
+
+	log.LogAttrs(ctx, level, "request",
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("status", status),
+		slog.Duration("latency", latency),
+		slog.String("request_id", requestID),
+	)
+}
@@ -1,20 +1,34 @@
 package app
 
 import (
+	"context"
+	"log/slog"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
 	"templates/internal/domain"
 	"templates/internal/handler"
 	"templates/internal/repo"
 	"templates/internal/service"
+	"templates/pkg/health"
 )
 
-func Run(appConfig domain.AppConfigs) error {
-	repo := repo.NewRepo()
-	service := service.NewService(repo)
+func Run(ctx context.Context, appConfig domain.AppConfigs, logger *slog.Logger) error {
+	health := health.New()
+	repo := repo.NewRepo(logger)
+	service := service.NewService(repo, logger)
+
+	app := gin.New()
+	app.Use(gin.Recovery(), handler.RequestLogger(logger))
+	handler.BindRoutes(app, handler.NewHandler(service, logger, health))
+
+	server := &http.Server{
+		Addr:    ":" + appConfig.App.Port,
+		Handler: app,
+	}
 
-	app := gin.Default()
-	handler.BindRoutes(app, handler.NewHandler(service))
+	shutdown := health.Drain(server.Shutdown, appConfig.App.DrainDelay)
 
-	return app.Run(":" + appConfig.App.Port)
+	return serve(ctx, server.ListenAndServe, shutdown, appConfig.App.ShutdownTimeout)
 }
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"templates/pkg/http"
+)
+
+var errNotReady = errors.New("not ready")
+
+// Healthz tells the app is alive, whatever the state of its dependencies.
+func (h *Handler) Healthz(ctx *gin.Context) {
+	successResponse(ctx, "ok")
+}
+
+// Readyz pings every dependency of the app and answers with their status
+// and latency, with a 503 when one of them fails or the app shuts down.
+func (h *Handler) Readyz(ctx *gin.Context) {
+	report := h.health.Check(ctx.Request.Context())
+	if !report.Ready {
+		ctx.JSON(http.StatusServiceUnavailable, jsonResponse{
+			Success: false,
+			Error:   errNotReady.Error(),
+			Data:    report,
+		})
+		return
+	}
+
+	successResponse(ctx, report)
+}
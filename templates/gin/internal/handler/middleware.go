@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"templates/pkg/logger"
+)
+
+// RequestLogger logs every request with its method, path, status, latency
+// and request id. The id is read from the X-Request-ID header, or generated,
+// and sent back in the response.
+func RequestLogger(log *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		requestID := logger.RequestID(ctx.GetHeader(logger.RequestIDHeader))
+		ctx.Header(logger.RequestIDHeader, requestID)
+
+		ctx.Next()
+
+		logger.Request(ctx.Request.Context(), log, ctx.Request.Method, ctx.Request.URL.Path, ctx.Writer.Status(), time.Since(start), requestID)
+	}
+}
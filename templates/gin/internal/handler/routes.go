@@ -4,4 +4,6 @@ import "github.com/gin-gonic/gin"
 
 func BindRoutes(app *gin.Engine, h *Handler) {
 	app.GET("/ping", h.Pong)
+	app.GET("/healthz", h.Healthz)
+	app.GET("/readyz", h.Readyz)
 }
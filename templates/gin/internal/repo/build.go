@@ -1,7 +1,13 @@
 package repo
 
-type Repo struct{}
+import "log/slog"
 
-func NewRepo() *Repo {
-	return &Repo{}
+type Repo struct {
+	logger *slog.Logger
+}
+
+func NewRepo(logger *slog.Logger) *Repo {
+	return &Repo{
+		logger: logger,
+	}
 }
@@ -1,11 +1,15 @@
 package service
 
+import "log/slog"
+
 type Service struct {
-	repo RepoInterface
+	repo   RepoInterface
+	logger *slog.Logger
 }
 
-func NewService(repo RepoInterface) *Service {
+func NewService(repo RepoInterface, logger *slog.Logger) *Service {
 	return &Service{
-		repo: repo,
+		repo:   repo,
+		logger: logger,
 	}
 }
@@ -0,0 +1,10 @@
+package service
+
+import "context"
+
+// Ping answers the sample Ping RPC, replace it with your own methods.
+func (s *Service) Ping(ctx context.Context) (string, error) {
+	s.logger.DebugContext(ctx, "ping")
+
+	return "pong", nil
+}
@@ -0,0 +1,10 @@
+package grpc
+
+type Config struct {
+	Enabled bool   `mapstructure:"enabled" yaml:"enabled"`
+	Port    string `mapstructure:"port" yaml:"port"`
+	// Reflection lets tools like grpcurl list the services.
+	Reflection bool `mapstructure:"reflection" yaml:"reflection"`
+	// HTTP keeps the HTTP server running next to gRPC.
+	HTTP bool `mapstructure:"http" yaml:"http"`
+}
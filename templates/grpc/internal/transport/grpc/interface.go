@@ -0,0 +1,7 @@
+package grpc
+
+import "context"
+
+type ServiceInterface interface {
+	Ping(ctx context.Context) (string, error)
+}
@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pingv1 "templates/gen/ping/v1"
+)
+
+type pingServer struct {
+	pingv1.UnimplementedPingServiceServer
+
+	service ServiceInterface
+}
+
+func (s *pingServer) Ping(ctx context.Context, req *pingv1.PingRequest) (*pingv1.PingResponse, error) {
+	message, err := s.service.Ping(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pingv1.PingResponse{Message: message}, nil
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	pingv1 "templates/gen/ping/v1"
+)
+
+type Server struct {
+	config Config
+	server *gogrpc.Server
+	health *health.Server
+}
+
+// NewServer registers the services on a gRPC server with the health service,
+// reflection if enabled and the logging and recovery interceptors.
+func NewServer(config Config, service ServiceInterface) *Server {
+	server := gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(recoveryInterceptor, loggingInterceptor),
+	)
+
+	pingv1.RegisterPingServiceServer(server, &pingServer{service: service})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	if config.Reflection {
+		reflection.Register(server)
+	}
+
+	return &Server{
+		config: config,
+		server: server,
+		health: healthServer,
+	}
+}
+
+// Run listens on the configured port and serves until the server stops.
+func (s *Server) Run() error {
+	listener, err := net.Listen("tcp", ":"+s.config.Port)
+	if err != nil {
+		return fmt.Errorf("can't listen on port %s: %w", s.config.Port, err)
+	}
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	return s.server.Serve(listener)
+}
+
+// Stop marks the services as not serving and waits for pending RPCs.
+func (s *Server) Stop() {
+	s.health.Shutdown()
+	s.server.GracefulStop()
+}
+
+// Shutdown stops the server like Stop, but cancels the pending RPCs once ctx
+// is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
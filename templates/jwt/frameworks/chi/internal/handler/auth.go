@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"templates/internal/domain"
+)
+
+type claimsKey struct{}
+
+// Login issues a token pair for valid credentials. It is a stub: check
+// req.Login and req.Password through the service, then answer with
+// h.issueTokens(userID).
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errBadResponse(w, err)
+		return
+	}
+
+	errNotImplementedResponse(w, errors.New("login isn't implemented yet"))
+}
+
+// Refresh issues a new token pair for a valid refresh token.
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errBadResponse(w, err)
+		return
+	}
+
+	claims, err := h.verifyToken(req.RefreshToken, domain.RefreshToken)
+	if err != nil {
+		errUnauthorizedResponse(w, err)
+		return
+	}
+
+	tokens, err := h.issueTokens(claims.Subject)
+	if err != nil {
+		errInternalServerErrorResponse(w, err)
+		return
+	}
+
+	successResponse(w, tokens)
+}
+
+// Me answers with the claims of the access token.
+func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
+	successResponse(w, claimsFrom(r.Context()))
+}
+
+// Auth wraps a handler, rejecting requests without a valid access token in
+// the Authorization header. The claims are stored in the request context
+// for claimsFrom.
+func (h *Handler) Auth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := h.authenticate(r.Header.Get("Authorization"))
+		if err != nil {
+			errUnauthorizedResponse(w, err)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims)))
+	}
+}
+
+// claimsFrom returns the claims Auth stored, nil on routes without Auth.
+func claimsFrom(ctx context.Context) *domain.Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*domain.Claims)
+	return claims
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"templates/internal/domain"
+)
+
+const claimsKey = "claims"
+
+// Login issues a token pair for valid credentials. It is a stub: check
+// req.Login and req.Password through the service, then answer with
+// h.issueTokens(userID).
+func (h *Handler) Login(ctx echo.Context) error {
+	var req loginRequest
+	if err := ctx.Bind(&req); err != nil {
+		return errBadResponse(ctx, err)
+	}
+
+	return errNotImplementedResponse(ctx, errors.New("login isn't implemented yet"))
+}
+
+// Refresh issues a new token pair for a valid refresh token.
+func (h *Handler) Refresh(ctx echo.Context) error {
+	var req refreshRequest
+	if err := ctx.Bind(&req); err != nil {
+		return errBadResponse(ctx, err)
+	}
+
+	claims, err := h.verifyToken(req.RefreshToken, domain.RefreshToken)
+	if err != nil {
+		return errUnauthorizedResponse(ctx, err)
+	}
+
+	tokens, err := h.issueTokens(claims.Subject)
+	if err != nil {
+		return errInternalServerErrorResponse(ctx, err)
+	}
+
+	return successResponse(ctx, tokens)
+}
+
+// Me answers with the claims of the access token.
+func (h *Handler) Me(ctx echo.Context) error {
+	return successResponse(ctx, claimsFrom(ctx))
+}
+
+// Auth is a middleware rejecting requests without a valid access token in
+// the Authorization header. It stores the claims for claimsFrom.
+func (h *Handler) Auth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		claims, err := h.authenticate(ctx.Request().Header.Get(echo.HeaderAuthorization))
+		if err != nil {
+			return errUnauthorizedResponse(ctx, err)
+		}
+
+		ctx.Set(claimsKey, claims)
+		return next(ctx)
+	}
+}
+
+// claimsFrom returns the claims Auth stored, nil on routes without Auth.
+func claimsFrom(ctx echo.Context) *domain.Claims {
+	claims, _ := ctx.Get(claimsKey).(*domain.Claims)
+	return claims
+}
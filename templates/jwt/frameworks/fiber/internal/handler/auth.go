@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"templates/internal/domain"
+)
+
+const claimsKey = "claims"
+
+// Login issues a token pair for valid credentials. It is a stub: check
+// req.Login and req.Password through the service, then answer with
+// h.issueTokens(userID).
+func (h *Handler) Login(ctx *fiber.Ctx) error {
+	var req loginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errBadResponse(ctx, err)
+	}
+
+	return errNotImplementedResponse(ctx, errors.New("login isn't implemented yet"))
+}
+
+// Refresh issues a new token pair for a valid refresh token.
+func (h *Handler) Refresh(ctx *fiber.Ctx) error {
+	var req refreshRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errBadResponse(ctx, err)
+	}
+
+	claims, err := h.verifyToken(req.RefreshToken, domain.RefreshToken)
+	if err != nil {
+		return errUnauthorizedResponse(ctx, err)
+	}
+
+	tokens, err := h.issueTokens(claims.Subject)
+	if err != nil {
+		return errInternalServerErrorResponse(ctx, err)
+	}
+
+	return successResponse(ctx, tokens)
+}
+
+// Me answers with the claims of the access token.
+func (h *Handler) Me(ctx *fiber.Ctx) error {
+	return successResponse(ctx, claimsFrom(ctx))
+}
+
+// Auth rejects requests without a valid access token in the Authorization
+// header and stores its claims for claimsFrom.
+func (h *Handler) Auth(ctx *fiber.Ctx) error {
+	claims, err := h.authenticate(ctx.Get(fiber.HeaderAuthorization))
+	if err != nil {
+		return errUnauthorizedResponse(ctx, err)
+	}
+
+	ctx.Locals(claimsKey, claims)
+	return ctx.Next()
+}
+
+// claimsFrom returns the claims Auth stored, nil on routes without Auth.
+func claimsFrom(ctx *fiber.Ctx) *domain.Claims {
+	claims, _ := ctx.Locals(claimsKey).(*domain.Claims)
+	return claims
+}
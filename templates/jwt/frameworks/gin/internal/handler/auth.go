@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"templates/internal/domain"
+)
+
+const claimsKey = "claims"
+
+// Login issues a token pair for valid credentials. It is a stub: check
+// req.Login and req.Password through the service, then answer with
+// h.issueTokens(userID).
+func (h *Handler) Login(ctx *gin.Context) {
+	var req loginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		errBadResponse(ctx, err)
+		return
+	}
+
+	errNotImplementedResponse(ctx, errors.New("login isn't implemented yet"))
+}
+
+// Refresh issues a new token pair for a valid refresh token.
+func (h *Handler) Refresh(ctx *gin.Context) {
+	var req refreshRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		errBadResponse(ctx, err)
+		return
+	}
+
+	claims, err := h.verifyToken(req.RefreshToken, domain.RefreshToken)
+	if err != nil {
+		errUnauthorizedResponse(ctx, err)
+		return
+	}
+
+	tokens, err := h.issueTokens(claims.Subject)
+	if err != nil {
+		errInternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	successResponse(ctx, tokens)
+}
+
+// Me answers with the claims of the access token.
+func (h *Handler) Me(ctx *gin.Context) {
+	successResponse(ctx, claimsFrom(ctx))
+}
+
+// Auth rejects requests without a valid access token in the Authorization
+// header and stores its claims for claimsFrom.
+func (h *Handler) Auth(ctx *gin.Context) {
+	claims, err := h.authenticate(ctx.GetHeader("Authorization"))
+	if err != nil {
+		errUnauthorizedResponse(ctx, err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Set(claimsKey, claims)
+	ctx.Next()
+}
+
+// claimsFrom returns the claims Auth stored, nil on routes without Auth.
+func claimsFrom(ctx *gin.Context) *domain.Claims {
+	claims, _ := ctx.Value(claimsKey).(*domain.Claims)
+	return claims
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "templates/pkg/jwt"
+
+// Token types of Claims, so a refresh token can't be used as an access token.
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// Claims are the claims of the tokens issued by the auth handlers. The
+// subject is the id of the user.
+type Claims struct {
+	Type string `json:"token_type"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"templates/internal/domain"
+)
+
+var errInvalidToken = errors.New("invalid or missing token")
+
+type loginRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// issueTokens signs an access and a refresh token for the user.
+func (h *Handler) issueTokens(userID string) (tokenResponse, error) {
+	access, err := h.tokens.Sign(domain.Claims{
+		Type:             domain.AccessToken,
+		RegisteredClaims: h.tokens.Registered(userID, h.tokens.AccessTTL()),
+	})
+	if err != nil {
+		return tokenResponse{}, err
+	}
+
+	refresh, err := h.tokens.Sign(domain.Claims{
+		Type:             domain.RefreshToken,
+		RegisteredClaims: h.tokens.Registered(userID, h.tokens.RefreshTTL()),
+	})
+	if err != nil {
+		return tokenResponse{}, err
+	}
+
+	return tokenResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(h.tokens.AccessTTL().Seconds()),
+	}, nil
+}
+
+// verifyToken returns the claims of a token of the given type.
+func (h *Handler) verifyToken(token, tokenType string) (*domain.Claims, error) {
+	var claims domain.Claims
+	if token == "" || h.tokens.Parse(token, &claims) != nil || claims.Type != tokenType {
+		return nil, errInvalidToken
+	}
+
+	return &claims, nil
+}
+
+// authenticate returns the claims of the access token of an Authorization
+// header like "Bearer <token>".
+func (h *Handler) authenticate(header string) (*domain.Claims, error) {
+	scheme, token, _ := strings.Cut(header, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return nil, errInvalidToken
+	}
+
+	return h.verifyToken(strings.TrimSpace(token), domain.AccessToken)
+}
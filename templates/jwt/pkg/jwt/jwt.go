@@ -0,0 +1,186 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algorithms supported by Manager.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// ErrInvalidToken is returned by Parse for malformed, expired or forged tokens.
+var ErrInvalidToken = errors.New("jwt: invalid token")
+
+// Claims is implemented by the claims types signed with Manager.
+type Claims = jwt.Claims
+
+// RegisteredClaims are the standard claims of RFC 7519, embedded by the
+// claims types of the app.
+type RegisteredClaims = jwt.RegisteredClaims
+
+type Config struct {
+	// Algorithm is HS256 (default) or RS256.
+	Algorithm string `mapstructure:"algorithm" yaml:"algorithm"`
+	// Secret signs HS256 tokens. The JWT_SECRET env variable overrides it.
+	Secret string `mapstructure:"secret" yaml:"secret"`
+	// PrivateKey and PublicKey are the PEM encoded RS256 keys, or the paths
+	// of their files. Without the private key tokens can only be verified.
+	PrivateKey string `mapstructure:"private_key" yaml:"private_key"`
+	PublicKey  string `mapstructure:"public_key" yaml:"public_key"`
+	Issuer     string `mapstructure:"issuer" yaml:"issuer"`
+	// AccessTTL and RefreshTTL default to 15m and 7 days.
+	AccessTTL  time.Duration `mapstructure:"access_ttl" yaml:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl" yaml:"refresh_ttl"`
+}
+
+// Manager signs and verifies tokens with the keys of a Config.
+type Manager struct {
+	config    Config
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+// New loads the keys of cfg. It overrides cfg.Secret with JWT_SECRET env
+// variable if set.
+func New(cfg Config) (*Manager, error) {
+	if envSecret := os.Getenv("JWT_SECRET"); envSecret != "" {
+		cfg.Secret = envSecret
+	}
+	if cfg.AccessTTL <= 0 {
+		cfg.AccessTTL = 15 * time.Minute
+	}
+	if cfg.RefreshTTL <= 0 {
+		cfg.RefreshTTL = 7 * 24 * time.Hour
+	}
+
+	m := &Manager{config: cfg}
+	switch strings.ToUpper(cfg.Algorithm) {
+	case "", HS256:
+		if cfg.Secret == "" {
+			return nil, errors.New("jwt: HS256 needs a secret, set jwt.secret or JWT_SECRET")
+		}
+		m.method = jwt.SigningMethodHS256
+		m.signKey = []byte(cfg.Secret)
+		m.verifyKey = []byte(cfg.Secret)
+	case RS256:
+		m.method = jwt.SigningMethodRS256
+		if err := m.loadRSAKeys(cfg.PrivateKey, cfg.PublicKey); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q (supported: %s, %s)", cfg.Algorithm, HS256, RS256)
+	}
+
+	return m, nil
+}
+
+// loadRSAKeys parses the RS256 keys. The public key is derived from the
+// private key when it isn't set.
+func (m *Manager) loadRSAKeys(privateKey, publicKey string) error {
+	if privateKey != "" {
+		pem, err := readPEM(privateKey)
+		if err != nil {
+			return fmt.Errorf("jwt: can't read the private key: %w", err)
+		}
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return fmt.Errorf("jwt: can't parse the private key: %w", err)
+		}
+		m.signKey = key
+		m.verifyKey = &key.PublicKey
+	}
+
+	if publicKey != "" {
+		pem, err := readPEM(publicKey)
+		if err != nil {
+			return fmt.Errorf("jwt: can't read the public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return fmt.Errorf("jwt: can't parse the public key: %w", err)
+		}
+		if signKey, ok := m.signKey.(*rsa.PrivateKey); ok && !signKey.PublicKey.Equal(key) {
+			return errors.New("jwt: the public key doesn't belong to the private key")
+		}
+		m.verifyKey = key
+	}
+
+	if m.verifyKey == nil {
+		return errors.New("jwt: RS256 needs jwt.private_key or jwt.public_key")
+	}
+
+	return nil
+}
+
+// readPEM returns value when it holds a PEM block and the content of the
+// file it names otherwise.
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
+}
+
+// AccessTTL returns how long access tokens are valid.
+func (m *Manager) AccessTTL() time.Duration {
+	return m.config.AccessTTL
+}
+
+// RefreshTTL returns how long refresh tokens are valid.
+func (m *Manager) RefreshTTL() time.Duration {
+	return m.config.RefreshTTL
+}
+
+// Registered returns the registered claims of a token for subject that
+// expires after ttl.
+func (m *Manager) Registered(subject string, ttl time.Duration) RegisteredClaims {
+	now := time.Now()
+
+	return RegisteredClaims{
+		Issuer:    m.config.Issuer,
+		Subject:   subject,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+}
+
+// Sign returns the signed token of claims.
+func (m *Manager) Sign(claims Claims) (string, error) {
+	if m.signKey == nil {
+		return "", errors.New("jwt: no private key to sign tokens with")
+	}
+
+	return jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+}
+
+// Parse verifies token and decodes its claims into claims. Tokens need an
+// expiry, and the issuer of the config when it is set.
+func (m *Manager) Parse(token string, claims Claims) error {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{m.method.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if m.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(m.config.Issuer))
+	}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return m.verifyKey, nil
+	}, options...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestManager(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+
+	t.Run("HS256 round trip", func(t *testing.T) {
+		m := newManager(t, Config{Secret: "secret", Issuer: "shop"})
+
+		token := sign(t, m, m.Registered("42", time.Minute))
+		var claims RegisteredClaims
+		if err := m.Parse(token, &claims); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if claims.Subject != "42" || claims.Issuer != "shop" {
+			t.Fatalf("unexpected claims: %+v", claims)
+		}
+	})
+
+	t.Run("HS256 needs a secret", func(t *testing.T) {
+		if _, err := New(Config{}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		m := newManager(t, Config{Secret: "secret"})
+
+		token := sign(t, m, m.Registered("42", -time.Minute))
+		if err := m.Parse(token, &RegisteredClaims{}); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("token without expiry", func(t *testing.T) {
+		m := newManager(t, Config{Secret: "secret"})
+
+		token := sign(t, m, RegisteredClaims{Subject: "42"})
+		if err := m.Parse(token, &RegisteredClaims{}); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("other issuer", func(t *testing.T) {
+		other := newManager(t, Config{Secret: "secret", Issuer: "other"})
+		m := newManager(t, Config{Secret: "secret", Issuer: "shop"})
+
+		token := sign(t, other, other.Registered("42", time.Minute))
+		if err := m.Parse(token, &RegisteredClaims{}); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		other := newManager(t, Config{Secret: "other"})
+		m := newManager(t, Config{Secret: "secret"})
+
+		token := sign(t, other, other.Registered("42", time.Minute))
+		if err := m.Parse(token, &RegisteredClaims{}); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("RS256 round trip with PEM values", func(t *testing.T) {
+		m := newManager(t, Config{Algorithm: RS256, PrivateKey: privatePEM, PublicKey: publicPEM})
+
+		token := sign(t, m, m.Registered("42", time.Minute))
+		var claims RegisteredClaims
+		if err := m.Parse(token, &claims); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if claims.Subject != "42" {
+			t.Fatalf("unexpected claims: %+v", claims)
+		}
+	})
+
+	t.Run("RS256 keys from files", func(t *testing.T) {
+		dir := t.TempDir()
+		privatePath, publicPath := filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem")
+		for path, content := range map[string]string{privatePath: privatePEM, publicPath: publicPEM} {
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		}
+
+		signer := newManager(t, Config{Algorithm: RS256, PrivateKey: privatePath})
+		verifier := newManager(t, Config{Algorithm: RS256, PublicKey: publicPath})
+
+		token := sign(t, signer, signer.Registered("42", time.Minute))
+		if err := verifier.Parse(token, &RegisteredClaims{}); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if _, err := verifier.Sign(verifier.Registered("42", time.Minute)); err == nil {
+			t.Fatal("expected a verify-only manager to refuse signing")
+		}
+	})
+
+	t.Run("RS256 with a foreign public key", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		otherDER, err := x509.MarshalPKIXPublicKey(&other.PublicKey)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		otherPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: otherDER}))
+
+		if _, err := New(Config{Algorithm: RS256, PrivateKey: privatePEM, PublicKey: otherPEM}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("RS256 without keys", func(t *testing.T) {
+		if _, err := New(Config{Algorithm: RS256}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("HS256 token sent to an RS256 manager", func(t *testing.T) {
+		m := newManager(t, Config{Algorithm: RS256, PublicKey: publicPEM})
+
+		// The classic confusion attack signs with the public key as HMAC secret
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, m.Registered("42", time.Minute)).SignedString([]byte(publicPEM))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if err := m.Parse(forged, &RegisteredClaims{}); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+}
+
+func newManager(t *testing.T, cfg Config) *Manager {
+	t.Helper()
+
+	m, err := New(cfg)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return m
+}
+
+func sign(t *testing.T, m *Manager, claims Claims) string {
+	t.Helper()
+
+	token, err := m.Sign(claims)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return token
+}
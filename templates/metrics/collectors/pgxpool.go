@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// AddPgxPool collects the pgxpool.Pool.Stat of pool, labelled with name.
+func (m *Metrics) AddPgxPool(name string, pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPgxPoolCollector(name, pool))
+}
+
+// pgxPoolCollector exports pgxpool.Stat the way collectors.NewDBStatsCollector
+// exports sql.DBStats.
+type pgxPoolCollector struct {
+	pool  *pgxpool.Pool
+	stats []pgxPoolStat
+}
+
+type pgxPoolStat struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	value     func(*pgxpool.Stat) float64
+}
+
+func newPgxPoolCollector(name string, pool *pgxpool.Pool) *pgxPoolCollector {
+	labels := prometheus.Labels{"db_name": name}
+	gauge := func(metric, help string, value func(*pgxpool.Stat) float64) pgxPoolStat {
+		return pgxPoolStat{prometheus.NewDesc("pgxpool_"+metric, help, nil, labels), prometheus.GaugeValue, value}
+	}
+	counter := func(metric, help string, value func(*pgxpool.Stat) float64) pgxPoolStat {
+		return pgxPoolStat{prometheus.NewDesc("pgxpool_"+metric, help, nil, labels), prometheus.CounterValue, value}
+	}
+
+	return &pgxPoolCollector{
+		pool: pool,
+		stats: []pgxPoolStat{
+			gauge("max_conns", "Maximum size of the pool.", func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) }),
+			gauge("total_conns", "Connections in the pool.", func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) }),
+			gauge("acquired_conns", "Connections in use.", func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) }),
+			gauge("idle_conns", "Idle connections.", func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) }),
+			gauge("constructing_conns", "Connections being opened.", func(s *pgxpool.Stat) float64 { return float64(s.ConstructingConns()) }),
+			counter("acquires_total", "Connections acquired from the pool.", func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) }),
+			counter("acquire_duration_seconds_total", "Time spent acquiring connections.", func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() }),
+			counter("empty_acquires_total", "Acquires that waited for a connection.", func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) }),
+			counter("canceled_acquires_total", "Acquires canceled by their context.", func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) }),
+			counter("new_conns_total", "Connections opened.", func(s *pgxpool.Stat) float64 { return float64(s.NewConnsCount()) }),
+			counter("max_lifetime_destroys_total", "Connections closed for exceeding their max lifetime.", func(s *pgxpool.Stat) float64 { return float64(s.MaxLifetimeDestroyCount()) }),
+			counter("max_idle_destroys_total", "Connections closed for exceeding their max idle time.", func(s *pgxpool.Stat) float64 { return float64(s.MaxIdleDestroyCount()) }),
+		},
+	}
+}
+
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, stat := range c.stats {
+		ch <- stat.desc
+	}
+}
+
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.pool.Stat()
+	for _, stat := range c.stats {
+		ch <- prometheus.MustNewConstMetric(stat.desc, stat.valueType, stat.value(stats))
+	}
+}
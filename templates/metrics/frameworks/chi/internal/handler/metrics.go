@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"templates/pkg/metrics"
+)
+
+// RequestMetrics records the rate, errors and duration of every request,
+// labelled with the route template it matched rather than its path.
+func RequestMetrics(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			m.Observe(r.Method, chi.RouteContext(r.Context()).RoutePattern(), recorder.status, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"templates/pkg/metrics"
+)
+
+// RequestMetrics records the rate, errors and duration of every request,
+// labelled with the route template it matched rather than its path.
+func RequestMetrics(m *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+
+			// Errors are written here so their status is recorded
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			m.Observe(ctx.Request().Method, ctx.Path(), ctx.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"templates/pkg/metrics"
+)
+
+// RequestMetrics records the rate, errors and duration of every request,
+// labelled with the route template it matched rather than its path.
+func RequestMetrics(m *metrics.Metrics) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+		middleware := ctx.Route()
+
+		err := ctx.Next()
+
+		// The error handler writes the status of returned errors later
+		status := ctx.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		// The route stays the one of this middleware when none matched
+		route := ""
+		if matched := ctx.Route(); matched != middleware {
+			route = matched.Path
+		}
+
+		// Fiber strings point into buffers reused by the next request
+		m.Observe(strings.Clone(ctx.Method()), strings.Clone(route), status, time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"templates/pkg/metrics"
+)
+
+// RequestMetrics records the rate, errors and duration of every request,
+// labelled with the route template it matched rather than its path.
+func RequestMetrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		m.Observe(ctx.Request.Method, ctx.FullPath(), ctx.Writer.Status(), time.Since(start))
+	}
+}
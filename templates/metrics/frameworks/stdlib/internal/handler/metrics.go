@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"templates/pkg/metrics"
+)
+
+// RequestMetrics records the rate, errors and duration of every request,
+// labelled with the route template it matched rather than its path. It has
+// to wrap the ServeMux, which sets the pattern of the request.
+func RequestMetrics(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			// Patterns start with the method, e.g. "GET /items/{id}"
+			route := r.Pattern
+			if _, path, ok := strings.Cut(route, " "); ok {
+				route = path
+			}
+
+			m.Observe(r.Method, route, recorder.status, time.Since(start))
+		})
+	}
+}
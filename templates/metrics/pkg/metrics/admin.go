@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// AdminServer returns the internal admin server listening on addr. It serves
+// the metrics and the net/http/pprof profiles under /debug/pprof/, so its
+// port must not be reachable from outside.
+func (m *Metrics) AdminServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.Handler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
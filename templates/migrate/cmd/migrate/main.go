@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+
+	"templates/internal/domain"
+	"templates/migrations"
+	"templates/pkg/config/viper"
+	"templates/pkg/migrate"
+	"templates/pkg/store"
+)
+
+const usage = `Usage:
+  go run ./cmd/migrate [flags] up         apply every pending migration
+  go run ./cmd/migrate [flags] down       roll back the last migration (-steps)
+  go run ./cmd/migrate [flags] version    print the latest applied version
+  go run ./cmd/migrate new <name>         create the files of a new migration
+
+Flags:
+`
+
+// errUsage is returned by run for an unknown command.
+var errUsage = errors.New("unknown command")
+
+func main() {
+	appConf := flag.String("config", "dev", "[prod,dev,locale]")
+	steps := flag.Int("steps", 1, "migrations rolled back by down, 0 for all")
+	dir := flag.String("dir", "migrations", "directory new writes the migration files to")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*appConf, *steps, *dir); err != nil {
+		if errors.Is(err, errUsage) {
+			flag.Usage()
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the command of the arguments. Errors are returned rather than
+// exiting, so the database is closed on every path.
+func run(appConf string, steps int, dir string) error {
+	if flag.Arg(0) == "new" {
+		paths, err := migrate.Create(dir, flag.Arg(1))
+		if err != nil {
+			return err
+		}
+		for _, p := range paths {
+			fmt.Println("created", p)
+		}
+		return nil
+	}
+
+	switch flag.Arg(0) {
+	case "up", "down", "version":
+	default:
+		return errUsage
+	}
+
+	var appConfig domain.AppConfigs
+	configFile := path.Join("etc", appConf+".yaml")
+
+	if err := viper.Parse(configFile, &appConfig); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+
+	ctx := context.Background()
+	db, err := store.NewClient(ctx, appConfig.DB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator := migrate.New(db, migrations.FS)
+
+	switch flag.Arg(0) {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migrations\n", applied)
+
+	case "down":
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d migrations\n", rolledBack)
+
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(version)
+	}
+
+	return nil
+}
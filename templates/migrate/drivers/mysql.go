@@ -0,0 +1,98 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// lockTimeout is how long, in seconds, a run waits for the migration lock.
+const lockTimeout = 60
+
+// New returns a Migrator for MySQL. Runs are serialised with GET_LOCK and
+// every migration runs in its own transaction. MySQL commits DDL statements
+// implicitly, so a migration failing halfway has to be fixed by hand.
+func New(db *sql.DB, source fs.FS) *Migrator {
+	return &Migrator{
+		source: source,
+		lock: func(ctx context.Context) (session, error) {
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			var locked sql.NullInt64
+			if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&locked); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("can't take the migration lock: %w", err)
+			}
+			if locked.Int64 != 1 {
+				conn.Close()
+				return nil, errors.New("can't take the migration lock: another migration is running")
+			}
+
+			return &mysqlSession{conn: conn}, nil
+		},
+	}
+}
+
+type mysqlSession struct {
+	conn *sql.Conn
+}
+
+func (s *mysqlSession) versions(ctx context.Context) (map[int64]bool, error) {
+	if _, err := s.conn.ExecContext(ctx, createTable); err != nil {
+		return nil, fmt.Errorf("can't create %s: %w", Table, err)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, "SELECT version FROM "+Table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions[version] = true
+	}
+
+	return versions, rows.Err()
+}
+
+func (s *mysqlSession) apply(ctx context.Context, m Migration, statements []string, down bool) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after Commit
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	if down {
+		_, err = tx.ExecContext(ctx, "DELETE FROM "+Table+" WHERE version = ?", m.Version)
+	} else {
+		_, err = tx.ExecContext(ctx, "INSERT INTO "+Table+" (version, name) VALUES (?, ?)", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *mysqlSession) close(ctx context.Context, _ error) error {
+	defer s.conn.Close()
+
+	var released sql.NullInt64
+	return s.conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", lockName).Scan(&released)
+}
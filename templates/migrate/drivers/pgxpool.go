@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// New returns a Migrator for PostgreSQL. Runs are serialised with a session
+// advisory lock and every migration runs in its own transaction.
+func New(db *pgxpool.Pool, source fs.FS) *Migrator {
+	return &Migrator{
+		source: source,
+		lock: func(ctx context.Context) (session, error) {
+			conn, err := db.Acquire(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock(hashtext($1))", lockName); err != nil {
+				conn.Release()
+				return nil, fmt.Errorf("can't take the migration lock: %w", err)
+			}
+
+			return &pgSession{conn: conn}, nil
+		},
+	}
+}
+
+type pgSession struct {
+	conn *pgxpool.Conn
+}
+
+func (s *pgSession) versions(ctx context.Context) (map[int64]bool, error) {
+	if _, err := s.conn.Exec(ctx, createTable); err != nil {
+		return nil, fmt.Errorf("can't create %s: %w", Table, err)
+	}
+
+	rows, err := s.conn.Query(ctx, "SELECT version FROM "+Table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions[version] = true
+	}
+
+	return versions, rows.Err()
+}
+
+func (s *pgSession) apply(ctx context.Context, m Migration, statements []string, down bool) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after Commit
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	if down {
+		_, err = tx.Exec(ctx, "DELETE FROM "+Table+" WHERE version = $1", m.Version)
+	} else {
+		_, err = tx.Exec(ctx, "INSERT INTO "+Table+" (version, name) VALUES ($1, $2)", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *pgSession) close(ctx context.Context, _ error) error {
+	defer s.conn.Release()
+
+	_, err := s.conn.Exec(ctx, "SELECT pg_advisory_unlock(hashtext($1))", lockName)
+	return err
+}
@@ -0,0 +1,86 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+)
+
+// New returns a Migrator for SQLite. A run takes the write lock of the
+// database with BEGIN IMMEDIATE and applies every migration in that one
+// transaction, so a failed run leaves the database unchanged.
+func New(db *sql.DB, source fs.FS) *Migrator {
+	return &Migrator{
+		source: source,
+		lock: func(ctx context.Context) (session, error) {
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("can't take the migration lock: %w", err)
+			}
+
+			return &sqliteSession{conn: conn}, nil
+		},
+	}
+}
+
+type sqliteSession struct {
+	conn *sql.Conn
+}
+
+func (s *sqliteSession) versions(ctx context.Context) (map[int64]bool, error) {
+	if _, err := s.conn.ExecContext(ctx, createTable); err != nil {
+		return nil, fmt.Errorf("can't create %s: %w", Table, err)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, "SELECT version FROM "+Table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions[version] = true
+	}
+
+	return versions, rows.Err()
+}
+
+func (s *sqliteSession) apply(ctx context.Context, m Migration, statements []string, down bool) error {
+	for _, statement := range statements {
+		if _, err := s.conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if down {
+		_, err = s.conn.ExecContext(ctx, "DELETE FROM "+Table+" WHERE version = ?", m.Version)
+	} else {
+		_, err = s.conn.ExecContext(ctx, "INSERT INTO "+Table+" (version, name) VALUES (?, ?)", m.Version, m.Name)
+	}
+
+	return err
+}
+
+func (s *sqliteSession) close(ctx context.Context, failed error) error {
+	defer s.conn.Close()
+
+	if failed != nil {
+		_, err := s.conn.ExecContext(ctx, "ROLLBACK")
+		return err
+	}
+
+	_, err := s.conn.ExecContext(ctx, "COMMIT")
+	return err
+}
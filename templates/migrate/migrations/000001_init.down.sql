@@ -0,0 +1,2 @@
+-- DOWN: init
+-- Revert the changes of 000001_init.up.sql here.
@@ -0,0 +1,2 @@
+-- UP: init
+-- Write the schema changes here, one statement per line ending with ";".
@@ -0,0 +1,10 @@
+// Package migrations holds the SQL migrations of the database, applied with
+// `make migrate-up`. Create new ones with `make migrate-new name=<name>`.
+package migrations
+
+import "embed"
+
+// FS holds the migration files.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,276 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Table records the versions of the applied migrations.
+const Table = "schema_migrations"
+
+// lockName identifies the lock held while migrating.
+const lockName = "schema_migrations"
+
+// createTable creates Table; the SQL is shared by every supported database.
+const createTable = `CREATE TABLE IF NOT EXISTS ` + Table + ` (
+	version BIGINT NOT NULL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a schema change read from the files
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// session is a connection holding the migration lock.
+type session interface {
+	// versions returns the applied versions, creating Table if needed.
+	versions(ctx context.Context) (map[int64]bool, error)
+	// apply runs the statements of m and records it as applied, or removes
+	// the record when down is true.
+	apply(ctx context.Context, m Migration, statements []string, down bool) error
+	// close releases the lock. failed is the error the run ended with.
+	close(ctx context.Context, failed error) error
+}
+
+// Migrator applies the migrations of source to a database. Only one
+// Migrator at a time runs against a database; the others wait for the lock.
+type Migrator struct {
+	source fs.FS
+	lock   func(ctx context.Context) (session, error)
+}
+
+// Up applies every pending migration in version order and returns how many
+// were applied.
+func (m *Migrator) Up(ctx context.Context) (applied int, err error) {
+	migrations, err := Load(m.source)
+	if err != nil {
+		return 0, err
+	}
+
+	s, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Join(err, s.close(context.WithoutCancel(ctx), err)) }()
+
+	done, err := s.versions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, migration := range migrations {
+		if done[migration.Version] {
+			continue
+		}
+
+		if err := s.apply(ctx, migration, Statements(migration.Up), false); err != nil {
+			return applied, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// Down rolls back the last steps applied migrations, newest first, and
+// returns how many were rolled back. steps < 1 rolls back all of them. It
+// stops at the first migration without a down file.
+func (m *Migrator) Down(ctx context.Context, steps int) (rolledBack int, err error) {
+	migrations, err := Load(m.source)
+	if err != nil {
+		return 0, err
+	}
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	s, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Join(err, s.close(context.WithoutCancel(ctx), err)) }()
+
+	done, err := s.versions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	versions := make([]int64, 0, len(done))
+	for version := range done {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	for _, version := range versions {
+		if steps > 0 && rolledBack == steps {
+			break
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			return rolledBack, fmt.Errorf("version %d is applied but has no migration files", version)
+		}
+		if migration.Down == "" {
+			return rolledBack, fmt.Errorf("migration %d has no down migration", migration.Version)
+		}
+		if err := s.apply(ctx, migration, Statements(migration.Down), true); err != nil {
+			return rolledBack, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		rolledBack++
+	}
+
+	return rolledBack, nil
+}
+
+// Version returns the latest applied version, or 0 if none is.
+func (m *Migrator) Version(ctx context.Context) (version int64, err error) {
+	s, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Join(err, s.close(context.WithoutCancel(ctx), err)) }()
+
+	done, err := s.versions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for v := range done {
+		version = max(version, v)
+	}
+
+	return version, nil
+}
+
+// Load reads the migrations at the root of source sorted by version. Every
+// migration needs an up file; a missing down file means it can't be rolled back.
+func Load(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("can't read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %q: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migrations %d_%s and %d_%s share a version", version, migration.Name, version, match[2])
+		}
+
+		body, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Create writes the empty up and down files of a new migration to dir,
+// numbered after the latest one, and returns their paths.
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(regexp.MustCompile(`\W+`).ReplaceAllString(strings.TrimSpace(name), "_"))
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return nil, errors.New("migration name is required")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	version := int64(1)
+	if n := len(migrations); n > 0 {
+		version = migrations[n-1].Version + 1
+	}
+
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%06d_%s.%s.sql", version, name, direction))
+		content := fmt.Sprintf("-- %s: %s\n", strings.ToUpper(direction), name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// Statements splits sql at the lines ending with ";" and drops the
+// statements holding only comments.
+func Statements(sql string) []string {
+	var statements []string
+	var current strings.Builder
+	empty := true
+
+	flush := func() {
+		if !empty {
+			statements = append(statements, strings.TrimSpace(current.String()))
+		}
+		current.Reset()
+		empty = true
+	}
+
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+			empty = false
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	flush()
+
+	return statements
+}
@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// fakeSession records the migrations applied to it in memory.
+type fakeSession struct {
+	applied map[int64]bool
+	ran     []string
+}
+
+func (s *fakeSession) versions(ctx context.Context) (map[int64]bool, error) {
+	return s.applied, nil
+}
+
+func (s *fakeSession) apply(ctx context.Context, m Migration, statements []string, down bool) error {
+	s.ran = append(s.ran, statements...)
+	if down {
+		delete(s.applied, m.Version)
+	} else {
+		s.applied[m.Version] = true
+	}
+	return nil
+}
+
+func (s *fakeSession) close(ctx context.Context, failed error) error {
+	return nil
+}
+
+func newFakeMigrator(source fstest.MapFS, applied ...int64) (*Migrator, *fakeSession) {
+	s := &fakeSession{applied: map[int64]bool{}}
+	for _, version := range applied {
+		s.applied[version] = true
+	}
+
+	return &Migrator{
+		source: source,
+		lock:   func(ctx context.Context) (session, error) { return s, nil },
+	}, s
+}
+
+func TestDown(t *testing.T) {
+	source := fstest.MapFS{
+		"000001_init.up.sql":     {Data: []byte("CREATE TABLE a (id INT);\n")},
+		"000001_init.down.sql":   {Data: []byte("DROP TABLE a;\n")},
+		"000002_notes.up.sql":    {Data: []byte("CREATE TABLE b (id INT);\n")},
+		"000002_notes.down.sql":  {Data: []byte("DROP TABLE b;\n")},
+		"000003_backfill.up.sql": {Data: []byte("INSERT INTO b VALUES (1);\n")},
+	}
+
+	t.Run("rolls back the newest migrations", func(t *testing.T) {
+		m, s := newFakeMigrator(source, 1, 2)
+
+		rolledBack, err := m.Down(context.Background(), 1)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if rolledBack != 1 || s.applied[2] || !s.applied[1] {
+			t.Fatalf("unexpected rollback: %d, applied %v", rolledBack, s.applied)
+		}
+		if len(s.ran) != 1 || s.ran[0] != "DROP TABLE b;" {
+			t.Fatalf("unexpected statements: %q", s.ran)
+		}
+	})
+
+	t.Run("migration without a down file", func(t *testing.T) {
+		m, s := newFakeMigrator(source, 1, 2, 3)
+
+		rolledBack, err := m.Down(context.Background(), 0)
+		if err == nil || !strings.Contains(err.Error(), "migration 3 has no down migration") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rolledBack != 0 || !s.applied[3] || len(s.ran) != 0 {
+			t.Fatalf("expected nothing to be rolled back: %d, applied %v, ran %q", rolledBack, s.applied, s.ran)
+		}
+	})
+}
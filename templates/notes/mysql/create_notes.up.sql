@@ -0,0 +1,5 @@
+CREATE TABLE notes (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	title VARCHAR(255) NOT NULL,
+	body TEXT NOT NULL
+);
@@ -0,0 +1,5 @@
+CREATE TABLE notes (
+	id BIGSERIAL PRIMARY KEY,
+	title TEXT NOT NULL,
+	body TEXT NOT NULL
+);
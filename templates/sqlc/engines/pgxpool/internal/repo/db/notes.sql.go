@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: notes.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createNote = `-- name: CreateNote :exec
+INSERT INTO notes (title, body) VALUES ($1, $2)
+`
+
+type CreateNoteParams struct {
+	Title string
+	Body  string
+}
+
+func (q *Queries) CreateNote(ctx context.Context, arg CreateNoteParams) error {
+	_, err := q.db.Exec(ctx, createNote, arg.Title, arg.Body)
+	return err
+}
+
+const deleteNote = `-- name: DeleteNote :exec
+DELETE FROM notes WHERE id = $1
+`
+
+func (q *Queries) DeleteNote(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteNote, id)
+	return err
+}
+
+const getNote = `-- name: GetNote :one
+SELECT id, title, body FROM notes WHERE id = $1
+`
+
+func (q *Queries) GetNote(ctx context.Context, id int64) (Note, error) {
+	row := q.db.QueryRow(ctx, getNote, id)
+	var i Note
+	err := row.Scan(&i.ID, &i.Title, &i.Body)
+	return i, err
+}
+
+const listNotes = `-- name: ListNotes :many
+SELECT id, title, body FROM notes ORDER BY id
+`
+
+func (q *Queries) ListNotes(ctx context.Context) ([]Note, error) {
+	rows, err := q.db.Query(ctx, listNotes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(&i.ID, &i.Title, &i.Body); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,11 @@
+-- name: GetNote :one
+SELECT id, title, body FROM notes WHERE id = $1;
+
+-- name: ListNotes :many
+SELECT id, title, body FROM notes ORDER BY id;
+
+-- name: CreateNote :exec
+INSERT INTO notes (title, body) VALUES ($1, $2);
+
+-- name: DeleteNote :exec
+DELETE FROM notes WHERE id = $1;
@@ -0,0 +1,11 @@
+-- name: GetNote :one
+SELECT id, title, body FROM notes WHERE id = ?;
+
+-- name: ListNotes :many
+SELECT id, title, body FROM notes ORDER BY id;
+
+-- name: CreateNote :exec
+INSERT INTO notes (title, body) VALUES (?, ?);
+
+-- name: DeleteNote :exec
+DELETE FROM notes WHERE id = ?;
@@ -0,0 +1,10 @@
+package repo
+
+import "templates/internal/repo/db"
+
+// newQueries wraps the client of the database in the queries sqlc generates
+// from queries/ into internal/repo/db. Repo embeds them, run `make sqlc`
+// after changing a query or a migration.
+func newQueries(conn db.DBTX) *db.Queries {
+	return db.New(conn)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+
+	"templates/internal/app"
+	"templates/internal/domain"
+	"templates/pkg/config/viper"
+	"templates/pkg/logger"
+)
+
+func main() {
+	appConf := flag.String("config", "dev", "[prod,dev,locale]")
+	flag.Parse()
+
+	var appConfig domain.AppConfigs
+	configFile := path.Join("etc", *appConf+".yaml")
+
+	if err := viper.Parse(configFile, &appConfig); err != nil {
+		panic(fmt.Sprintf("error parse configs error:%+v", err))
+	}
+
+	logger, err := logger.New(appConfig.Log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "can't create logger: %v\n", err)
+		os.Exit(1)
+	}
+	// The gRPC interceptors and the log package write through it too
+	slog.SetDefault(logger)
+
+	// Cancelled on SIGINT or SIGTERM, which shuts the app down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err = app.Run(ctx, appConfig, logger)
+	stop()
+
+	if err != nil {
+		logger.Error("can't run app", "err", err)
+		os.Exit(1)
+	}
+}
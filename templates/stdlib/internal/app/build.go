@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"templates/internal/domain"
+	"templates/internal/handler"
+	"templates/internal/repo"
+	"templates/internal/service"
+	"templates/pkg/health"
+)
+
+func Run(ctx context.Context, appConfig domain.AppConfigs, logger *slog.Logger) error {
+	health := health.New()
+	repo := repo.NewRepo(logger)
+	service := service.NewService(repo, logger)
+
+	mux := http.NewServeMux()
+	handler.BindRoutes(mux, handler.NewHandler(service, logger, health))
+
+	server := &http.Server{
+		Addr:              ":" + appConfig.App.Port,
+		Handler:           handler.RequestLogger(logger)(mux),
+		ReadHeaderTimeout: appConfig.App.ReadHeaderTimeout,
+		ReadTimeout:       appConfig.App.ReadTimeout,
+		WriteTimeout:      appConfig.App.WriteTimeout,
+		IdleTimeout:       appConfig.App.IdleTimeout,
+	}
+
+	shutdown := health.Drain(server.Shutdown, appConfig.App.DrainDelay)
+
+	return serve(ctx, server.ListenAndServe, shutdown, appConfig.App.ShutdownTimeout)
+}
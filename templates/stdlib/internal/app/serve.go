@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultShutdownTimeout is used when app.shutdown_timeout isn't set.
+const defaultShutdownTimeout = 10 * time.Second
+
+// serve runs start until it fails or ctx is cancelled. Then it calls shutdown,
+// which gives in-flight requests up to timeout to finish, and waits for start
+// to return.
+func serve(ctx context.Context, start func() error, shutdown func(context.Context) error, timeout time.Duration) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- start()
+	}()
+
+	select {
+	case err := <-errs:
+		return ignoreClosed(err)
+	case <-ctx.Done():
+	}
+
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	if err := shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("can't shut down: %w", err)
+	}
+
+	return ignoreClosed(<-errs)
+}
+
+// ignoreClosed drops the error servers return once they were shut down.
+func ignoreClosed(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
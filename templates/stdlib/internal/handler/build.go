@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"templates/pkg/health"
+)
+
+type Handler struct {
+	service ServiceInterface
+	logger  *slog.Logger
+	health  *health.Health
+}
+
+func NewHandler(service ServiceInterface, logger *slog.Logger, health *health.Health) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+		health:  health,
+	}
+}
+
+type jsonResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Data    any    `json:"data"`
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body jsonResponse) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func response(w http.ResponseWriter, statusCode int, data any) {
+	writeJSON(w, statusCode, jsonResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+func successResponse(w http.ResponseWriter, data any) {
+	response(w, http.StatusOK, data)
+}
+
+func errResponse(w http.ResponseWriter, statusCode int, error error) {
+	writeJSON(w, statusCode, jsonResponse{
+		Success: false,
+		Error:   error.Error(),
+	})
+}
+
+func errBadResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusBadRequest, err)
+}
+
+func errUnauthorizedResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusUnauthorized, err)
+}
+
+func errForbiddenResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusForbidden, err)
+}
+
+func errNotFoundResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusNotFound, err)
+}
+
+func errConflictResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusConflict, err)
+}
+
+func errTooManyRequestsResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusTooManyRequests, err)
+}
+
+func errInternalServerErrorResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusInternalServerError, err)
+}
+
+func errNotImplementedResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusNotImplemented, err)
+}
+
+func errServiceUnavailableResponse(w http.ResponseWriter, err error) {
+	errResponse(w, http.StatusServiceUnavailable, err)
+}
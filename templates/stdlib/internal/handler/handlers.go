@@ -0,0 +1,9 @@
+package handler
+
+import (
+	"net/http"
+)
+
+func (h *Handler) Pong(w http.ResponseWriter, r *http.Request) {
+	successResponse(w, "pong")
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+)
+
+var errNotReady = errors.New("not ready")
+
+// Healthz tells the app is alive, whatever the state of its dependencies.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	successResponse(w, "ok")
+}
+
+// Readyz pings every dependency of the app and answers with their status
+// and latency, with a 503 when one of them fails or the app shuts down.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.health.Check(r.Context())
+	if !report.Ready {
+		writeJSON(w, http.StatusServiceUnavailable, jsonResponse{
+			Success: false,
+			Error:   errNotReady.Error(),
+			Data:    report,
+		})
+		return
+	}
+
+	successResponse(w, report)
+}
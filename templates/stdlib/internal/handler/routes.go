@@ -0,0 +1,9 @@
+package handler
+
+import "net/http"
+
+func BindRoutes(mux *http.ServeMux, h *Handler) {
+	mux.HandleFunc("GET /ping", h.Pong)
+	mux.HandleFunc("GET /healthz", h.Healthz)
+	mux.HandleFunc("GET /readyz", h.Readyz)
+}
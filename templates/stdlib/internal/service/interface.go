@@ -0,0 +1,3 @@
+package service
+
+type RepoInterface interface{}
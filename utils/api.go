@@ -0,0 +1,603 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// API describes the HTTP API of a generated project.
+type API struct {
+	Endpoints []Endpoint
+	// Envelope is the jsonResponse struct every response is wrapped in.
+	Envelope *TypeSchema
+}
+
+// Endpoint is a route of a generated project together with the types its
+// handler reads from the request body and passes to successResponse.
+type Endpoint struct {
+	BoundRoute
+	// Request is the type bound from the JSON body, nil if the handler binds none.
+	Request *TypeSchema
+	// Response is the data of the success response, nil if it is unknown or nil.
+	Response *TypeSchema
+}
+
+// TypeSchema describes how a Go type is encoded as JSON.
+type TypeSchema struct {
+	// Name is the qualified name of a named struct, e.g. domain.Product.
+	Name string
+	// Kind is one of object, array, string, integer, number, boolean or any.
+	Kind   string
+	Format string
+	Fields []SchemaField
+	// Items is the element type of an array.
+	Items *TypeSchema
+	// Values is the value type of a map.
+	Values *TypeSchema
+	// Ref marks a reference to a struct that is being described already.
+	Ref bool
+}
+
+// SchemaField is a JSON encoded field of a struct.
+type SchemaField struct {
+	Name      string
+	Schema    *TypeSchema
+	OmitEmpty bool
+}
+
+// bindMethods are the gin, fiber and encoding/json methods decoding the request body.
+var bindMethods = map[string]bool{
+	"ShouldBindJSON": true, "BindJSON": true, "ShouldBind": true, "Bind": true, "BodyParser": true, "Decode": true,
+}
+
+// AnalyzeProject reads the routes registered in internal/handler/routes.go
+// of the project in dir and the request and response types of their handlers.
+func AnalyzeProject(dir string, d Dialect) (API, error) {
+	handlerDir := filepath.Join(dir, "internal", "handler")
+
+	routes, err := BoundRoutes(filepath.Join(handlerDir, "routes.go"), "BindRoutes", d)
+	if err != nil {
+		return API{}, err
+	}
+
+	index, err := indexTypes(dir)
+	if err != nil {
+		return API{}, err
+	}
+
+	handlers, funcs, services, err := handlerDecls(handlerDir)
+	if err != nil {
+		return API{}, err
+	}
+
+	api := API{Envelope: index.named("handler", "jsonResponse", map[string]bool{})}
+	if api.Envelope.Kind != "object" {
+		api.Envelope = &TypeSchema{Name: "handler.jsonResponse", Kind: "object", Fields: []SchemaField{
+			{Name: "success", Schema: &TypeSchema{Kind: "boolean"}},
+			{Name: "error", Schema: &TypeSchema{Kind: "string"}},
+			{Name: "data", Schema: &TypeSchema{Kind: "any"}},
+		}}
+	}
+
+	api.Endpoints = make([]Endpoint, 0, len(routes))
+	for _, route := range routes {
+		endpoint := Endpoint{BoundRoute: route}
+		if fn, ok := handlers[route.Handler]; ok {
+			scope := funcScope{fn: fn, handlers: handlers, funcs: funcs, services: services}
+			request, response := scope.bodyTypes()
+			if request != nil {
+				endpoint.Request = index.schema(request, "handler", map[string]bool{})
+			}
+			if response != nil {
+				endpoint.Response = index.schema(response, "handler", map[string]bool{})
+			}
+		}
+		api.Endpoints = append(api.Endpoints, endpoint)
+	}
+
+	return api, nil
+}
+
+// handlerDecls returns the methods of Handler, the other functions and the
+// result types of the ServiceInterface methods in the handler package.
+func handlerDecls(dir string) (map[string]*ast.FuncDecl, map[string]*ast.FuncDecl, map[string][]ast.Expr, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	handlers := map[string]*ast.FuncDecl{}
+	funcs := map[string]*ast.FuncDecl{}
+	services := map[string][]ast.Expr{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				switch {
+				case decl.Body == nil:
+				case decl.Recv == nil:
+					funcs[decl.Name.Name] = decl
+				case receiverName(decl) == "Handler":
+					handlers[decl.Name.Name] = decl
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok || typeSpec.Name.Name != "ServiceInterface" {
+						continue
+					}
+					iface, ok := typeSpec.Type.(*ast.InterfaceType)
+					if !ok {
+						continue
+					}
+					for _, method := range iface.Methods.List {
+						fn, ok := method.Type.(*ast.FuncType)
+						if !ok || len(method.Names) == 0 {
+							continue
+						}
+						services[method.Names[0].Name] = fieldTypes(fn.Results)
+					}
+				}
+			}
+		}
+	}
+
+	return handlers, funcs, services, nil
+}
+
+// funcScope resolves the types of the variables of a handler.
+type funcScope struct {
+	fn       *ast.FuncDecl
+	handlers map[string]*ast.FuncDecl
+	funcs    map[string]*ast.FuncDecl
+	services map[string][]ast.Expr
+}
+
+// bodyTypes returns the type expressions of the bound request body and of
+// the data passed to successResponse.
+func (s funcScope) bodyTypes() (request, response ast.Expr) {
+	ast.Inspect(s.fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			if bindMethods[fun.Sel.Name] && request == nil {
+				request = s.exprType(call.Args[0])
+			}
+		case *ast.Ident:
+			if fun.Name == "successResponse" && len(call.Args) == 2 && response == nil {
+				response = s.exprType(call.Args[1])
+			}
+		}
+		return true
+	})
+
+	return request, response
+}
+
+// exprType returns the type expression of expr, or nil if it is unknown.
+func (s funcScope) exprType(expr ast.Expr) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.UnaryExpr:
+		if expr.Op == token.AND {
+			return s.exprType(expr.X)
+		}
+	case *ast.CompositeLit:
+		return expr.Type
+	case *ast.BasicLit:
+		switch expr.Kind {
+		case token.STRING, token.CHAR:
+			return ast.NewIdent("string")
+		case token.INT:
+			return ast.NewIdent("int")
+		case token.FLOAT:
+			return ast.NewIdent("float64")
+		}
+	case *ast.Ident:
+		return s.varType(expr.Name)
+	case *ast.CallExpr:
+		if results := s.callResults(expr); len(results) > 0 {
+			return results[0]
+		}
+	}
+
+	return nil
+}
+
+// varType returns the type of the variable name declared in the handler.
+func (s funcScope) varType(name string) ast.Expr {
+	var typ ast.Expr
+	ast.Inspect(s.fn.Body, func(n ast.Node) bool {
+		if typ != nil {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, ident := range n.Names {
+				if ident.Name != name {
+					continue
+				}
+				if n.Type != nil {
+					typ = n.Type
+				} else if i < len(n.Values) {
+					typ = s.exprType(n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || ident.Name != name {
+					continue
+				}
+				if len(n.Rhs) == len(n.Lhs) {
+					typ = s.exprType(n.Rhs[i])
+				} else if call, ok := n.Rhs[0].(*ast.CallExpr); ok && len(n.Rhs) == 1 {
+					if results := s.callResults(call); i < len(results) {
+						typ = results[i]
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return typ
+}
+
+// callResults returns the result types of calls of ServiceInterface methods
+// like h.service.GetProduct(...), of other Handler methods like h.issueTokens(...)
+// and of functions of the handler package.
+func (s funcScope) callResults(call *ast.CallExpr) []ast.Expr {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if fn, ok := s.funcs[fun.Name]; ok {
+			return fieldTypes(fn.Type.Results)
+		}
+	case *ast.SelectorExpr:
+		x, ok := fun.X.(*ast.Ident)
+		if !ok || x.Name != receiverVar(s.fn) {
+			return s.services[fun.Sel.Name]
+		}
+		if fn, ok := s.handlers[fun.Sel.Name]; ok {
+			return fieldTypes(fn.Type.Results)
+		}
+	}
+
+	return nil
+}
+
+// receiverVar returns the name of the receiver of a method, e.g. "h".
+func receiverVar(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+		return ""
+	}
+
+	return fn.Recv.List[0].Names[0].Name
+}
+
+// typeIndex holds the type declarations of a project by package name.
+type typeIndex map[string]map[string]ast.Expr
+
+// indexTypes parses the type declarations of every package below dir.
+func indexTypes(dir string) (typeIndex, error) {
+	index := typeIndex{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != dir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil
+		}
+
+		pkg := file.Name.Name
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if index[pkg] == nil {
+					index[pkg] = map[string]ast.Expr{}
+				}
+				index[pkg][typeSpec.Name.Name] = typeSpec.Type
+			}
+		}
+		return nil
+	})
+
+	return index, err
+}
+
+// schema describes the type expression expr used in package pkg. seen holds
+// the structs being described, to stop at recursive types.
+func (index typeIndex) schema(expr ast.Expr, pkg string, seen map[string]bool) *TypeSchema {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if schema := builtinSchema(expr.Name); schema != nil {
+			return schema
+		}
+		return index.named(pkg, expr.Name, seen)
+	case *ast.SelectorExpr:
+		x, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return &TypeSchema{Kind: "any"}
+		}
+		if x.Name == "time" && expr.Sel.Name == "Time" {
+			return &TypeSchema{Kind: "string", Format: "date-time"}
+		}
+		return index.named(x.Name, expr.Sel.Name, seen)
+	case *ast.StarExpr:
+		return index.schema(expr.X, pkg, seen)
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &TypeSchema{Kind: "string", Format: "byte"}
+		}
+		return &TypeSchema{Kind: "array", Items: index.schema(expr.Elt, pkg, seen)}
+	case *ast.MapType:
+		return &TypeSchema{Kind: "object", Values: index.schema(expr.Value, pkg, seen)}
+	case *ast.StructType:
+		return index.structSchema(expr, pkg, seen)
+	}
+
+	return &TypeSchema{Kind: "any"}
+}
+
+// named describes the type name declared in package pkg.
+func (index typeIndex) named(pkg, name string, seen map[string]bool) *TypeSchema {
+	expr, ok := index[pkg][name]
+	if !ok {
+		return &TypeSchema{Kind: "any"}
+	}
+
+	qualified := pkg + "." + name
+	_, isStruct := expr.(*ast.StructType)
+	if seen[qualified] {
+		// An alias of an imported type of the same name, e.g.
+		// `type Claims = jwt.Claims` in package jwt, resolves to itself
+		if !isStruct {
+			return &TypeSchema{Kind: "any"}
+		}
+		return &TypeSchema{Name: qualified, Kind: "object", Ref: true}
+	}
+
+	seen[qualified] = true
+	defer delete(seen, qualified)
+
+	if !isStruct {
+		return index.schema(expr, pkg, seen)
+	}
+
+	schema := index.schema(expr, pkg, seen)
+	schema.Name = qualified
+	return schema
+}
+
+// structSchema describes the JSON encoding of a struct, following the rules
+// of encoding/json for tags and embedded structs.
+func (index typeIndex) structSchema(expr *ast.StructType, pkg string, seen map[string]bool) *TypeSchema {
+	schema := &TypeSchema{Kind: "object"}
+	for _, field := range expr.Fields.List {
+		name, options := "", ""
+		if field.Tag != nil {
+			if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+				name, options, _ = strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+			}
+		}
+		if name == "-" && options == "" {
+			continue
+		}
+
+		fieldSchema := index.schema(field.Type, pkg, seen)
+		if len(field.Names) == 0 {
+			// embedded structs without a tag are flattened
+			if name == "" && fieldSchema.Kind == "object" && !fieldSchema.Ref {
+				schema.Fields = append(schema.Fields, fieldSchema.Fields...)
+				continue
+			}
+			if name == "" {
+				name = embeddedName(field.Type)
+			}
+			schema.Fields = append(schema.Fields, SchemaField{Name: name, Schema: fieldSchema, OmitEmpty: options == "omitempty"})
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			fieldName := name
+			if fieldName == "" {
+				fieldName = ident.Name
+			}
+			schema.Fields = append(schema.Fields, SchemaField{
+				Name:      fieldName,
+				Schema:    fieldSchema,
+				OmitEmpty: strings.Contains(options, "omitempty"),
+			})
+		}
+	}
+
+	return schema
+}
+
+// Example returns a value encoding to an example of the schema.
+func (s *TypeSchema) Example() any {
+	switch s.Kind {
+	case "object":
+		object := exampleObject{}
+		if s.Ref {
+			return object
+		}
+		for _, field := range s.Fields {
+			object = append(object, exampleField{field.Name, field.Schema.Example()})
+		}
+		return object
+	case "array":
+		return []any{s.Items.Example()}
+	case "string":
+		if s.Format == "date-time" {
+			return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		}
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	default:
+		return nil
+	}
+}
+
+// exampleObject is a JSON object keeping the order of the struct fields.
+type exampleObject []exampleField
+
+type exampleField struct {
+	name  string
+	value any
+}
+
+func (o exampleObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// builtinSchema describes a predeclared type, nil if name isn't one.
+func builtinSchema(name string) *TypeSchema {
+	switch name {
+	case "string":
+		return &TypeSchema{Kind: "string"}
+	case "bool":
+		return &TypeSchema{Kind: "boolean"}
+	case "int", "int64", "uint", "uint64", "uintptr":
+		return &TypeSchema{Kind: "integer", Format: "int64"}
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32", "byte", "rune":
+		return &TypeSchema{Kind: "integer", Format: "int32"}
+	case "float32":
+		return &TypeSchema{Kind: "number", Format: "float"}
+	case "float64":
+		return &TypeSchema{Kind: "number", Format: "double"}
+	case "error":
+		return &TypeSchema{Kind: "string"}
+	case "any":
+		return &TypeSchema{Kind: "any"}
+	}
+
+	return nil
+}
+
+// embeddedName returns the field name of an embedded type.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	}
+
+	return ""
+}
+
+// receiverName returns the type name of the receiver of a method.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	return embeddedName(fn.Recv.List[0].Type)
+}
+
+// fieldTypes returns the type of every entry of a field list, repeating the
+// type of grouped names like (a, b int).
+func fieldTypes(list *ast.FieldList) []ast.Expr {
+	if list == nil {
+		return nil
+	}
+
+	var types []ast.Expr
+	for _, field := range list.List {
+		for n := max(len(field.Names), 1); n > 0; n-- {
+			types = append(types, field.Type)
+		}
+	}
+
+	return types
+}
+
+// parseDir parses the non-test Go files of dir.
+func parseDir(dir string) ([]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
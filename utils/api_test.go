@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+func TestAnalyzeProject(t *testing.T) {
+	d, err := HandlerDialect(domain.FrameworkTypeGin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api, err := AnalyzeProject("testdata/api", d)
+	if err != nil {
+		t.Fatal("error analyzing project:", err)
+	}
+
+	t.Run("routes", func(t *testing.T) {
+		var got []string
+		for _, e := range api.Endpoints {
+			got = append(got, e.Method+" "+e.Path+" "+e.Handler+" "+strings.Join(e.Groups, "."))
+		}
+
+		want := []string{
+			"GET /ping Pong ",
+			"POST /api/products CreateProduct api.products",
+			"GET /api/products ListProducts api.products",
+			"GET /me Me ",
+			"GET /me/token Token ",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("unexpected routes:\n%s", strings.Join(got, "\n"))
+		}
+	})
+
+	t.Run("types", func(t *testing.T) {
+		create := api.Endpoints[1]
+		if create.Request == nil || create.Request.Name != "domain.Product" {
+			t.Fatalf("expected domain.Product request, got %+v", create.Request)
+		}
+		if create.Response == nil || create.Response.Name != "domain.Product" {
+			t.Fatalf("expected domain.Product response, got %+v", create.Response)
+		}
+
+		var fields []string
+		for _, f := range create.Request.Fields {
+			fields = append(fields, f.Name+":"+f.Schema.Kind+f.Schema.Format)
+		}
+		if got := strings.Join(fields, " "); got != "id:integerint64 title:string tags:array created_at:stringdate-time" {
+			t.Fatalf("unexpected fields: %s", got)
+		}
+
+		list := api.Endpoints[2]
+		if list.Request != nil || list.Response == nil || list.Response.Kind != "array" {
+			t.Fatalf("expected no request and an array response, got %+v %+v", list.Request, list.Response)
+		}
+		if pong := api.Endpoints[0].Response; pong == nil || pong.Kind != "string" {
+			t.Fatalf("expected string response, got %+v", pong)
+		}
+		if me := api.Endpoints[3].Response; me == nil || me.Name != "handler.user" {
+			t.Fatalf("expected the result of userFrom as response, got %+v", me)
+		}
+		if token := api.Endpoints[4].Response; token == nil || token.Kind != "string" {
+			t.Fatalf("expected the result of h.sign as response, got %+v", token)
+		}
+	})
+
+	t.Run("openapi", func(t *testing.T) {
+		out, err := yaml.Marshal(BuildOpenAPI("api", "http://localhost:8080", api))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, want := range []string{
+			"/api/products:",
+			"$ref: '#/components/schemas/Product'",
+			"$ref: '#/components/schemas/jsonResponse'",
+			"operationId: ListProducts",
+			`"200":`,
+			"format: date-time",
+		} {
+			if !strings.Contains(string(out), want) {
+				t.Errorf("expected %q in:\n%s", want, out)
+			}
+		}
+	})
+}
@@ -3,9 +3,11 @@ package utils
 import (
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"os"
 	"strings"
 )
@@ -14,6 +16,7 @@ import (
 // `filePath`: path to the .go file
 // `structName`: the name of the struct to modify
 // `field`: a single field like "Age int" or "Email string `json:\"email\"`"
+// If the struct already has a field with that name, it does nothing.
 func AppendFieldStruct(filePath, structName, field string) error {
 	// Step 1: Create the file set and parse the file
 	fset := token.NewFileSet()
@@ -56,9 +59,19 @@ func AppendFieldStruct(filePath, structName, field string) error {
 				continue
 			}
 
+			found = true
+
+			// Keep an existing field with the same name
+			for _, existing := range structType.Fields.List {
+				for _, name := range existing.Names {
+					if name.Name == fieldName {
+						return false
+					}
+				}
+			}
+
 			// Append the new field
 			structType.Fields.List = append(structType.Fields.List, newField)
-			found = true
 			return false
 		}
 
@@ -148,6 +161,7 @@ func AddImportToFile(filePath string, importPath string) error {
 }
 
 // AppendFuncArgument adds a new argument to the specified function in the Go source file.
+// If the function already has an argument with that name, it does nothing.
 func AppendFuncArgument(filePath, funcName, newArgName, newArgType string) error {
 	fset := token.NewFileSet()
 
@@ -167,15 +181,27 @@ func AppendFuncArgument(filePath, funcName, newArgName, newArgType string) error
 			continue
 		}
 
-		// Create a new field (argument)
+		// Create a new field (argument), placed at the closing paren so the
+		// printer keeps it on the line of the others
+		closing := funcDecl.Type.Params.Closing
 		newField := &ast.Field{
-			Names: []*ast.Ident{ast.NewIdent(newArgName)},
+			Names: []*ast.Ident{{NamePos: closing, Name: newArgName}},
 			Type:  parseExprFromType(newArgType),
 		}
+		ast.Inspect(newField.Type, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				ident.NamePos = closing
+			}
+			return true
+		})
+
+		found = true
+		if funcHasParam(funcDecl, newArgName) {
+			break
+		}
 
 		// Append the argument
 		funcDecl.Type.Params.List = append(funcDecl.Type.Params.List, newField)
-		found = true
 		break
 	}
 
@@ -214,6 +240,7 @@ func parseExprFromType(typ string) ast.Expr {
 
 // AddReturnFieldToConstructor modifies the return expression of a constructor
 // (e.g. NewRepo) to include a struct literal field initialization (e.g. db: db).
+// If the field is already initialized, it does nothing.
 func AddReturnFieldToConstructor(filePath, funcName, fieldName string) error {
 	fset := token.NewFileSet()
 
@@ -247,6 +274,13 @@ func AddReturnFieldToConstructor(filePath, funcName, fieldName string) error {
 				continue
 			}
 
+			// Keep an existing field initialization
+			for _, elt := range structLit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok && types.ExprString(kv.Key) == fieldName {
+					return false
+				}
+			}
+
 			// Add field to composite literal
 			structLit.Elts = append(structLit.Elts, &ast.KeyValueExpr{
 				Key:   ast.NewIdent(fieldName),
@@ -268,7 +302,98 @@ func AddReturnFieldToConstructor(filePath, funcName, fieldName string) error {
 	return printer.Fprint(file, fset, node)
 }
 
+// ReplaceStructField replaces the field fieldName of a struct with field,
+// e.g. "db *pgxpool.Pool" with the embedded "*db.Queries". If the struct
+// already has field, it does nothing.
+func ReplaceStructField(filePath, structName, fieldName, field string) error {
+	return replaceNode(filePath, func(node *ast.File) (ast.Node, string, error) {
+		for _, decl := range node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != structName {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					return nil, "", fmt.Errorf("%q in %s is not a struct", structName, filePath)
+				}
+
+				for _, existing := range structType.Fields.List {
+					if nodeString(existing) == strings.Join(strings.Fields(field), "") {
+						return nil, "", nil
+					}
+				}
+				for _, existing := range structType.Fields.List {
+					if len(existing.Names) == 1 && existing.Names[0].Name == fieldName {
+						return existing, field, nil
+					}
+				}
+
+				return nil, "", fmt.Errorf("struct %q has no field %q", structName, fieldName)
+			}
+		}
+
+		return nil, "", fmt.Errorf("struct %q not found in %s", structName, filePath)
+	})
+}
+
+// ReplaceReturnField replaces the fieldName initialization of the struct
+// literal a constructor returns with key: value, e.g. "db: db" with
+// "Queries: newQueries(db)". If key is already initialized, it does nothing.
+func ReplaceReturnField(filePath, funcName, fieldName, key, value string) error {
+	return replaceNode(filePath, func(node *ast.File) (ast.Node, string, error) {
+		for _, decl := range node.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != funcName || funcDecl.Body == nil {
+				continue
+			}
+
+			for _, stmt := range funcDecl.Body.List {
+				ret, ok := stmt.(*ast.ReturnStmt)
+				if !ok || len(ret.Results) == 0 {
+					continue
+				}
+				unary, ok := ret.Results[0].(*ast.UnaryExpr)
+				if !ok || unary.Op != token.AND {
+					continue
+				}
+				structLit, ok := unary.X.(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+
+				var replaced ast.Node
+				for _, elt := range structLit.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					switch types.ExprString(kv.Key) {
+					case key:
+						return nil, "", nil
+					case fieldName:
+						replaced = kv
+					}
+				}
+				if replaced != nil {
+					return replaced, key + ": " + value, nil
+				}
+			}
+
+			return nil, "", fmt.Errorf("%q doesn't return a literal initializing %q", funcName, fieldName)
+		}
+
+		return nil, "", fmt.Errorf("function %q not found in %s", funcName, filePath)
+	})
+}
+
 // AddArgumentToFunctionCall adds an argument to the specified function call (e.g., repo.NewRepo)
+// If the call already passes the same argument, it does nothing.
 func AddArgumentToFunctionCall(filePath, fullFuncName, argName string) error {
 	fset := token.NewFileSet()
 	node, err := parser.ParseFile(fset, filePath, nil, parser.AllErrors)
@@ -294,11 +419,9 @@ func AddArgumentToFunctionCall(filePath, fullFuncName, argName string) error {
 		if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 			if pkgIdent, ok := selExpr.X.(*ast.Ident); ok {
 				if pkgIdent.Name == pkgName && selExpr.Sel.Name == funcName {
-					// Add argument only if the call currently has no arguments
-					if len(callExpr.Args) == 0 {
-						callExpr.Args = []ast.Expr{
-							ast.NewIdent(argName),
-						}
+					// Add argument only if the call doesn't pass it yet
+					if !callHasArgument(callExpr, argName) {
+						callExpr.Args = append(callExpr.Args, ast.NewIdent(argName))
 					}
 				}
 			}
@@ -315,3 +438,320 @@ func AddArgumentToFunctionCall(filePath, fullFuncName, argName string) error {
 
 	return printer.Fprint(outFile, fset, node)
 }
+
+// StructFields returns the fields of a struct in a Go source file, mapping each
+// field name to its type as written in the source (e.g. "DB": "pgxpool.Config").
+func StructFields(filePath, structName string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || typeSpec.Name.Name != structName {
+				continue
+			}
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return nil, fmt.Errorf("%q in %s is not a struct", structName, filePath)
+			}
+
+			fields := map[string]string{}
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					fields[name.Name] = types.ExprString(field.Type)
+				}
+			}
+			return fields, nil
+		}
+	}
+
+	return nil, fmt.Errorf("struct %q not found in %s", structName, filePath)
+}
+
+// funcHasParam reports whether the function declares a parameter with the given name.
+func funcHasParam(funcDecl *ast.FuncDecl, name string) bool {
+	for _, param := range funcDecl.Type.Params.List {
+		for _, ident := range param.Names {
+			if ident.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// callHasArgument reports whether the call passes an argument written as arg.
+func callHasArgument(callExpr *ast.CallExpr, arg string) bool {
+	for _, existing := range callExpr.Args {
+		if ident, ok := existing.(*ast.Ident); ok && ident.Name == arg {
+			return true
+		}
+		if types.ExprString(existing) == arg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AppendInterfaceMethod adds a method like "Get(ctx context.Context) error" to
+// an interface in a Go source file. If the interface already has a method
+// with that name, it does nothing.
+func AppendInterfaceMethod(filePath, interfaceName, method string) error {
+	methodName, _, _ := strings.Cut(method, "(")
+	methodName = strings.TrimSpace(methodName)
+
+	return spliceFile(filePath, func(node *ast.File) (token.Pos, string, error) {
+		for _, decl := range node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != interfaceName {
+					continue
+				}
+
+				iface, ok := typeSpec.Type.(*ast.InterfaceType)
+				if !ok {
+					return token.NoPos, "", fmt.Errorf("%q is not an interface", interfaceName)
+				}
+
+				for _, existing := range iface.Methods.List {
+					for _, name := range existing.Names {
+						if name.Name == methodName {
+							return token.NoPos, "", nil
+						}
+					}
+				}
+
+				if len(iface.Methods.List) == 0 {
+					return iface.Methods.Closing, "\n" + method + "\n", nil
+				}
+				return iface.Methods.Closing, method + "\n", nil
+			}
+		}
+
+		return token.NoPos, "", fmt.Errorf("interface %q not found in %s", interfaceName, filePath)
+	})
+}
+
+// AppendStatementToFunc adds a statement like `app.GET("/ping", h.Pong)` at the
+// end of a function body, before a trailing return. If the function already
+// contains the same statement, it does nothing.
+func AppendStatementToFunc(filePath, funcName, stmt string) error {
+	newStmt := parseStmt(stmt)
+	if newStmt == nil {
+		return fmt.Errorf("invalid statement: %q", stmt)
+	}
+
+	return spliceFile(filePath, func(node *ast.File) (token.Pos, string, error) {
+		for _, decl := range node.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != funcName || funcDecl.Body == nil {
+				continue
+			}
+
+			for _, existing := range funcDecl.Body.List {
+				if nodeString(existing) == nodeString(newStmt) {
+					return token.NoPos, "", nil
+				}
+			}
+
+			pos := funcDecl.Body.Rbrace
+			if n := len(funcDecl.Body.List); n > 0 {
+				if ret, ok := funcDecl.Body.List[n-1].(*ast.ReturnStmt); ok {
+					pos = ret.Pos()
+				}
+			}
+
+			return pos, stmt + "\n", nil
+		}
+
+		return token.NoPos, "", fmt.Errorf("function %q not found in %s", funcName, filePath)
+	})
+}
+
+// InsertStatementBefore adds a statement to a function body right before the
+// first top level statement starting with before, e.g. "handler.BindRoutes(".
+// If the function already contains the same statement, it does nothing.
+func InsertStatementBefore(filePath, funcName, before, stmt string) error {
+	newStmt := parseStmt(stmt)
+	if newStmt == nil {
+		return fmt.Errorf("invalid statement: %q", stmt)
+	}
+	anchor := strings.Join(strings.Fields(before), "")
+
+	return spliceFile(filePath, func(node *ast.File) (token.Pos, string, error) {
+		for _, decl := range node.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != funcName || funcDecl.Body == nil {
+				continue
+			}
+
+			for _, existing := range funcDecl.Body.List {
+				if nodeString(existing) == nodeString(newStmt) {
+					return token.NoPos, "", nil
+				}
+			}
+
+			for _, existing := range funcDecl.Body.List {
+				if strings.HasPrefix(nodeString(existing), anchor) {
+					return existing.Pos(), stmt + "\n", nil
+				}
+			}
+
+			return token.NoPos, "", fmt.Errorf("no statement starting with %q in %q", before, funcName)
+		}
+
+		return token.NoPos, "", fmt.Errorf("function %q not found in %s", funcName, filePath)
+	})
+}
+
+// HasFuncParam reports whether the function funcName in filePath has a
+// parameter called param.
+func HasFuncParam(filePath, funcName, param string) (bool, error) {
+	node, err := parser.ParseFile(token.NewFileSet(), filePath, nil, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	for _, decl := range node.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Name.Name == funcName {
+			return funcHasParam(funcDecl, param), nil
+		}
+	}
+
+	return false, fmt.Errorf("function %q not found in %s", funcName, filePath)
+}
+
+// FuncParamTypes returns the types of the parameters of funcName in
+// filePath as written, one entry per parameter name.
+func FuncParamTypes(filePath, funcName string) ([]string, error) {
+	node, err := parser.ParseFile(token.NewFileSet(), filePath, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	for _, decl := range node.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Name.Name != funcName {
+			continue
+		}
+
+		var types []string
+		for _, param := range funcDecl.Type.Params.List {
+			for range max(len(param.Names), 1) {
+				types = append(types, nodeString(param.Type))
+			}
+		}
+		return types, nil
+	}
+
+	return nil, fmt.Errorf("function %q not found in %s", funcName, filePath)
+}
+
+// spliceFile inserts the text returned by find at the returned position and
+// gofmts the result. A NoPos position with no error leaves the file untouched.
+func spliceFile(filePath string, find func(node *ast.File) (token.Pos, string, error)) error {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	pos, text, err := find(node)
+	if err != nil || !pos.IsValid() {
+		return err
+	}
+
+	offset := fset.Position(pos).Offset
+	updated := make([]byte, 0, len(src)+len(text))
+	updated = append(updated, src[:offset]...)
+	updated = append(updated, text...)
+	updated = append(updated, src[offset:]...)
+
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return fmt.Errorf("failed to format updated file: %w", err)
+	}
+
+	return os.WriteFile(filePath, formatted, 0o644)
+}
+
+// replaceNode replaces the source of the node returned by find with the
+// returned text and gofmts the result. A nil node with no error leaves the
+// file untouched.
+func replaceNode(filePath string, find func(node *ast.File) (ast.Node, string, error)) error {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	replaced, text, err := find(node)
+	if err != nil || replaced == nil {
+		return err
+	}
+
+	start, end := fset.Position(replaced.Pos()).Offset, fset.Position(replaced.End()).Offset
+	updated := make([]byte, 0, len(src)+len(text))
+	updated = append(updated, src[:start]...)
+	updated = append(updated, text...)
+	updated = append(updated, src[end:]...)
+
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return fmt.Errorf("failed to format updated file: %w", err)
+	}
+
+	return os.WriteFile(filePath, formatted, 0o644)
+}
+
+// parseStmt parses a single statement, returning nil if it is invalid.
+func parseStmt(stmt string) ast.Stmt {
+	node, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _() {\n"+stmt+"\n}", 0)
+	if err != nil {
+		return nil
+	}
+
+	body := node.Decls[0].(*ast.FuncDecl).Body.List
+	if len(body) != 1 {
+		return nil
+	}
+	return body[0]
+}
+
+// nodeString prints a node with all whitespace removed, so nodes parsed from
+// different sources can be compared.
+func nodeString(node ast.Node) string {
+	var b strings.Builder
+	if err := printer.Fprint(&b, token.NewFileSet(), node); err != nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(b.String()), "")
+}
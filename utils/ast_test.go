@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const astSource = `package app
+
+import (
+	"context"
+)
+
+type Repo struct {
+	db string
+}
+
+type Service interface {
+	Get(ctx context.Context) error
+}
+
+func NewRepo(db string) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func Run(ctx context.Context) error {
+	repo := NewRepo("db")
+	service := repo.NewService(repo)
+	handler.BindRoutes(service)
+
+	return nil
+}
+`
+
+func TestASTHelpers(t *testing.T) {
+	tests := []struct {
+		name  string
+		apply func(path string) error
+		want  string
+	}{
+		{
+			name:  "AppendFieldStruct",
+			apply: func(path string) error { return AppendFieldStruct(path, "Repo", "cache string") },
+			want:  "cache",
+		},
+		{
+			name:  "AddImportToFile",
+			apply: func(path string) error { return AddImportToFile(path, "log/slog") },
+			want:  `"log/slog"`,
+		},
+		{
+			name:  "AppendFuncArgument",
+			apply: func(path string) error { return AppendFuncArgument(path, "NewRepo", "cache", "string") },
+			want:  "cache string",
+		},
+		{
+			name:  "AddReturnFieldToConstructor",
+			apply: func(path string) error { return AddReturnFieldToConstructor(path, "NewRepo", "cache") },
+			want:  "cache: cache",
+		},
+		{
+			name:  "AddArgumentToFunctionCall",
+			apply: func(path string) error { return AddArgumentToFunctionCall(path, "repo.NewService", "cache") },
+			want:  "repo.NewService(repo, cache)",
+		},
+		{
+			name: "AppendInterfaceMethod",
+			apply: func(path string) error {
+				return AppendInterfaceMethod(path, "Service", "List(ctx context.Context) ([]string, error)")
+			},
+			want: "List(ctx context.Context) ([]string, error)",
+		},
+		{
+			name:  "AppendStatementToFunc",
+			apply: func(path string) error { return AppendStatementToFunc(path, "Run", `handler.Ping("/ping")`) },
+			want:  "handler.Ping(\"/ping\")\n\treturn nil",
+		},
+		{
+			name: "InsertStatementBefore",
+			apply: func(path string) error {
+				return InsertStatementBefore(path, "Run", "handler.BindRoutes(", "service.Start()")
+			},
+			want: "service.Start()\n\thandler.BindRoutes(service)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.go")
+			if err := os.WriteFile(path, []byte(astSource), 0o644); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if err := tt.apply(path); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			first, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if !strings.Contains(string(first), tt.want) {
+				t.Fatalf("missing %q:\n%s", tt.want, first)
+			}
+
+			// A second run must leave the file as it is
+			if err := tt.apply(path); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			second, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if string(second) != string(first) {
+				t.Fatalf("second run changed the file:\n%s\nwant:\n%s", second, first)
+			}
+		})
+	}
+
+	t.Run("missing function", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.go")
+		if err := os.WriteFile(path, []byte(astSource), 0o644); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if err := AppendStatementToFunc(path, "Stop", "repo.Close()"); err == nil {
+			t.Fatal("expected an error")
+		}
+		if err := InsertStatementBefore(path, "Run", "server.Start(", "repo.Close()"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
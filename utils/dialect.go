@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+// Dialect holds the framework specific pieces used to generate handler code
+// that matches the templates/<framework>/internal/handler package.
+type Dialect struct {
+	// Import is the import path of the framework.
+	Import string
+	// HandlerImport is the package handler signatures use, e.g. "net/http"
+	// for routers of net/http handlers.
+	HandlerImport string
+	// Params are the parameters of a handler, e.g. "ctx *gin.Context".
+	Params string
+	// Writer is the handler argument the response helpers take, e.g. "ctx".
+	Writer string
+	// Returns reports whether handlers and response helpers return an error.
+	Returns bool
+	// Context is the expression passing the request context.Context on.
+	Context string
+	// Bind decodes the JSON body into a pointer, e.g. "ctx.ShouldBindJSON(%s)".
+	Bind string
+	// BindImport is the package Bind needs besides Import, if any.
+	BindImport string
+	// Param reads a path parameter by name, e.g. `ctx.Param(%q)`.
+	Param string
+	// Query reads a query parameter by name, e.g. `ctx.Query(%q)`.
+	Query string
+	// Router is the type of the value BindRoutes registers routes on.
+	Router string
+	// RouterVar is the name of the router in BindRoutes and app.Run.
+	RouterVar string
+	// Group is the type returned when grouping routes, empty if the router
+	// can't group them.
+	Group string
+	// Methods maps HTTP methods to the router method registering them.
+	Methods map[string]string
+	// Patterns reports whether routes are registered with HandleFunc and a
+	// net/http pattern like "GET /items/{id}" instead of Methods.
+	Patterns bool
+	// BraceParams reports whether path parameters are written {name}
+	// instead of :name.
+	BraceParams bool
+	// WrapHTTP adapts a net/http handler to a route handler, e.g. "gin.WrapH(%s)".
+	WrapHTTP string
+	// WrapHTTPImport is the package WrapHTTP needs besides Import, if any.
+	WrapHTTPImport string
+}
+
+// HandlerDialect returns the Dialect of a framework.
+func HandlerDialect(framework domain.FrameworkType) (Dialect, error) {
+	switch framework {
+	case domain.FrameworkTypeGin:
+		return Dialect{
+			Import:        "github.com/gin-gonic/gin",
+			HandlerImport: "github.com/gin-gonic/gin",
+			Params:        "ctx *gin.Context",
+			Writer:        "ctx",
+			Context:       "ctx",
+			Bind:          "ctx.ShouldBindJSON(%s)",
+			Param:         "ctx.Param(%q)",
+			Query:         "ctx.Query(%q)",
+			Router:        "*gin.Engine",
+			RouterVar:     "app",
+			Group:         "*gin.RouterGroup",
+			Methods: map[string]string{
+				"GET": "GET", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH",
+				"DELETE": "DELETE", "HEAD": "HEAD", "OPTIONS": "OPTIONS",
+			},
+			WrapHTTP: "gin.WrapH(%s)",
+		}, nil
+	case domain.FrameworkTypeFiber:
+		return Dialect{
+			Import:        "github.com/gofiber/fiber/v2",
+			HandlerImport: "github.com/gofiber/fiber/v2",
+			Params:        "ctx *fiber.Ctx",
+			Writer:        "ctx",
+			Returns:       true,
+			Context:       "ctx.UserContext()",
+			Bind:          "ctx.BodyParser(%s)",
+			Param:         "ctx.Params(%q)",
+			Query:         "ctx.Query(%q)",
+			Router:        "*fiber.App",
+			RouterVar:     "app",
+			Group:         "fiber.Router",
+			Methods: map[string]string{
+				"GET": "Get", "POST": "Post", "PUT": "Put", "PATCH": "Patch",
+				"DELETE": "Delete", "HEAD": "Head", "OPTIONS": "Options",
+			},
+			WrapHTTP:       "adaptor.HTTPHandler(%s)",
+			WrapHTTPImport: "github.com/gofiber/fiber/v2/middleware/adaptor",
+		}, nil
+	case domain.FrameworkTypeEcho:
+		return Dialect{
+			Import:        "github.com/labstack/echo/v4",
+			HandlerImport: "github.com/labstack/echo/v4",
+			Params:        "ctx echo.Context",
+			Writer:        "ctx",
+			Returns:       true,
+			Context:       "ctx.Request().Context()",
+			Bind:          "ctx.Bind(%s)",
+			Param:         "ctx.Param(%q)",
+			Query:         "ctx.QueryParam(%q)",
+			Router:        "*echo.Echo",
+			RouterVar:     "app",
+			Group:         "*echo.Group",
+			Methods: map[string]string{
+				"GET": "GET", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH",
+				"DELETE": "DELETE", "HEAD": "HEAD", "OPTIONS": "OPTIONS",
+			},
+			WrapHTTP: "echo.WrapHandler(%s)",
+		}, nil
+	case domain.FrameworkTypeChi:
+		return Dialect{
+			Import:        "github.com/go-chi/chi/v5",
+			HandlerImport: "net/http",
+			Params:        "w http.ResponseWriter, r *http.Request",
+			Writer:        "w",
+			Context:       "r.Context()",
+			Bind:          "json.NewDecoder(r.Body).Decode(%s)",
+			BindImport:    "encoding/json",
+			Param:         "r.PathValue(%q)",
+			Query:         "r.URL.Query().Get(%q)",
+			Router:        "*chi.Mux",
+			RouterVar:     "router",
+			Methods: map[string]string{
+				"GET": "Get", "POST": "Post", "PUT": "Put", "PATCH": "Patch",
+				"DELETE": "Delete", "HEAD": "Head", "OPTIONS": "Options",
+			},
+			BraceParams: true,
+			WrapHTTP:    "%s.ServeHTTP",
+		}, nil
+	case domain.FrameworkTypeStdlib:
+		return Dialect{
+			Import:        "net/http",
+			HandlerImport: "net/http",
+			Params:        "w http.ResponseWriter, r *http.Request",
+			Writer:        "w",
+			Context:       "r.Context()",
+			Bind:          "json.NewDecoder(r.Body).Decode(%s)",
+			BindImport:    "encoding/json",
+			Param:         "r.PathValue(%q)",
+			Query:         "r.URL.Query().Get(%q)",
+			Router:        "*http.ServeMux",
+			RouterVar:     "mux",
+			Patterns:      true,
+			BraceParams:   true,
+			WrapHTTP:      "%s.ServeHTTP",
+		}, nil
+	default:
+		return Dialect{}, fmt.Errorf("unsupported framework %q", framework)
+	}
+}
+
+// Signature returns the declaration line of a Handler method.
+func (d Dialect) Signature(name string) string {
+	if d.Returns {
+		return fmt.Sprintf("func (h *Handler) %s(%s) error", name, d.Params)
+	}
+
+	return fmt.Sprintf("func (h *Handler) %s(%s)", name, d.Params)
+}
+
+// Call returns a call of a response helper on the handler argument, e.g.
+// Call("errBadResponse", "err") gives "errBadResponse(ctx, err)".
+func (d Dialect) Call(helper string, args ...string) string {
+	return fmt.Sprintf("%s(%s)", helper, strings.Join(append([]string{d.Writer}, args...), ", "))
+}
+
+// Respond returns a call of a response helper that ends the handler early.
+func (d Dialect) Respond(call string) string {
+	if d.Returns {
+		return "return " + call
+	}
+
+	return call + "\nreturn"
+}
+
+// Finish returns a call of a response helper as the last statement of a handler.
+func (d Dialect) Finish(call string) string {
+	if d.Returns {
+		return "return " + call
+	}
+
+	return call
+}
+
+// Route returns a route registration like `app.GET("/ping", h.Pong)`.
+// path uses :name parameters, which are converted for net/http patterns.
+func (d Dialect) Route(router, method, path, handler string) string {
+	if d.BraceParams {
+		path = braceParams(path, d.Patterns)
+	}
+	if d.Patterns {
+		if path == "" {
+			path = "/"
+		}
+		return fmt.Sprintf("%s.HandleFunc(%q, %s)", router, method+" "+path, handler)
+	}
+
+	return fmt.Sprintf("%s.%s(%q, %s)", router, d.Methods[method], path, handler)
+}
+
+// braceParams converts :name parameters to {name}. A *name catch-all
+// becomes the {name...} wildcard of net/http patterns, or chi's bare *.
+func braceParams(path string, patterns bool) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "{" + strings.TrimSuffix(segment[1:], "?") + "}"
+		case strings.HasPrefix(segment, "*") && patterns:
+			segments[i] = "{" + segment[1:] + "...}"
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = "*"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// colonParams converts the {name} and {name...} wildcards of a path back
+// to :name and *name parameters.
+func colonParams(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		name := strings.TrimSuffix(segment, "}")[1:]
+		if name == "$" {
+			segments[i] = ""
+		} else if rest, ok := strings.CutSuffix(name, "..."); ok {
+			segments[i] = "*" + rest
+		} else {
+			segments[i] = ":" + name
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
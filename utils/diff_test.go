@@ -0,0 +1,36 @@
+package utils
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("equal texts", func(t *testing.T) {
+		if diff := UnifiedDiff("a", "b", "x\ny\n", "x\ny\n"); diff != "" {
+			t.Fatalf("expected no diff, got %q", diff)
+		}
+	})
+
+	t.Run("changed line", func(t *testing.T) {
+		want := "--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n"
+		if diff := UnifiedDiff("a", "b", "one\ntwo\nthree\n", "one\n2\nthree\n"); diff != want {
+			t.Fatalf("unexpected diff:\n%s", diff)
+		}
+	})
+
+	t.Run("new file", func(t *testing.T) {
+		want := "--- /dev/null\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n"
+		if diff := UnifiedDiff("/dev/null", "b", "", "x\ny\n"); diff != want {
+			t.Fatalf("unexpected diff:\n%s", diff)
+		}
+	})
+
+	t.Run("distant changes get separate hunks", func(t *testing.T) {
+		old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+		updated := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n"
+		want := "--- a\n+++ b\n" +
+			"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+			"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n"
+		if diff := UnifiedDiff("a", "b", old, updated); diff != want {
+			t.Fatalf("unexpected diff:\n%s", diff)
+		}
+	})
+}
@@ -31,15 +31,17 @@ func AppendToFile(path string, data []byte) error {
 	return nil
 }
 
-func UpdatePackageNameOnGCI(name string) error {
-	data, err := os.ReadFile(path.Join(name, ".golangci.yml"))
+// UpdatePackageNameOnGCI sets the module path as the goimports local prefix
+// in the .golangci.yml of the project in dir.
+func UpdatePackageNameOnGCI(dir, module string) error {
+	data, err := os.ReadFile(path.Join(dir, ".golangci.yml"))
 	if err != nil {
 		return err
 	}
 
-	updatedFile := strings.ReplaceAll(string(data), "<package_name>", name)
+	updatedFile := strings.ReplaceAll(string(data), "<package_name>", module)
 
-	err = os.WriteFile(path.Join(name, ".golangci.yml"), []byte(updatedFile), 0o644)
+	err = os.WriteFile(path.Join(dir, ".golangci.yml"), []byte(updatedFile), 0o644)
 	if err != nil {
 		return err
 	}
@@ -1,64 +1,97 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
-	"os"
 
 	"github.com/MH-KodaCore/goarm/domain"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func OpenForm() domain.App {
-	// Clear before project name input
-	clearScreen()
-	projectForm := newProjectNameForm()
+// OpenForm asks for every value that is still empty in app and returns the
+// completed App. Values that are already set are kept as they are.
+func OpenForm(app domain.App) (domain.App, error) {
+	if app.Module == "" {
+		// Clear before project name input
+		clearScreen()
+		projectForm := newProjectNameForm()
+
+		if _, err := tea.NewProgram(projectForm).Run(); err != nil {
+			return app, fmt.Errorf("failed to run project name form: %w", err)
+		}
 
-	if _, err := tea.NewProgram(projectForm).Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to run project name form: %v\n", err)
-		os.Exit(1)
+		app.Module = projectForm.GetAppName()
+		if len(app.Module) == 0 {
+			return app, errors.New("project name cannot be empty")
+		}
 	}
 
-	projectName := projectForm.GetAppName()
-	if len(projectName) == 0 {
-		fmt.Fprintln(os.Stderr, "Project name cannot be empty.")
-		os.Exit(1)
+	if app.Name == "" {
+		app.Name = ProjectDir(app.Module)
 	}
 
-	// Clear before framework selection
-	clearScreen()
-	frameworkForm := newFrameworkSelectForm()
+	if app.Framework == "" {
+		// Clear before framework selection
+		clearScreen()
+		frameworkForm := newFrameworkSelectForm()
 
-	if _, err := tea.NewProgram(&frameworkForm).Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to run framework select form: %v\n", err)
-		os.Exit(1)
+		if _, err := tea.NewProgram(&frameworkForm).Run(); err != nil {
+			return app, fmt.Errorf("failed to run framework select form: %w", err)
+		}
+
+		if len(frameworkForm.GetChoice()) == 0 {
+			return app, errors.New("project framework cannot be empty")
+		}
+		app.Framework = domain.FrameworkType(frameworkForm.GetChoice())
 	}
 
-	if len(frameworkForm.GetChoice()) == 0 {
-		fmt.Fprintln(os.Stderr, "Project framework cannot be empty.")
-		os.Exit(1)
+	if app.DbType == "" {
+		// Clear before database selection
+		clearScreen()
+		databaseForm := newDatabaseSelectForm()
+
+		if _, err := tea.NewProgram(&databaseForm).Run(); err != nil {
+			return app, fmt.Errorf("failed to run database select form: %w", err)
+		}
+
+		if len(databaseForm.GetChoice()) == 0 {
+			return app, errors.New("project database cannot be empty")
+		}
+		app.DbType = domain.DbType(databaseForm.GetChoice())
 	}
 
-	// Clear before database selection
-	clearScreen()
-	databaseForm := newDatabaseSelectForm()
+	if app.DataAccess == "" && app.DbType.IsSQL() {
+		// Clear before data access selection
+		clearScreen()
+		ormForm := newORMSelectForm()
+
+		if _, err := tea.NewProgram(&ormForm).Run(); err != nil {
+			return app, fmt.Errorf("failed to run data access select form: %w", err)
+		}
 
-	if _, err := tea.NewProgram(&databaseForm).Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to run database select form: %v\n", err)
-		os.Exit(1)
+		if len(ormForm.GetChoice()) == 0 {
+			return app, errors.New("project data access cannot be empty")
+		}
+		app.DataAccess = domain.DataAccess(ormForm.GetChoice())
 	}
 
-	if len(databaseForm.GetChoice()) == 0 {
-		fmt.Fprintln(os.Stderr, "Project database cannot be empty.")
-		os.Exit(1)
+	if app.Cache == "" {
+		// Clear before cache selection
+		clearScreen()
+		cacheForm := newCacheSelectForm()
+
+		if _, err := tea.NewProgram(&cacheForm).Run(); err != nil {
+			return app, fmt.Errorf("failed to run cache select form: %w", err)
+		}
+
+		if len(cacheForm.GetChoice()) == 0 {
+			return app, errors.New("project cache cannot be empty")
+		}
+		app.Cache = domain.CacheType(cacheForm.GetChoice())
 	}
 
-	// Return app struct (capture DB choice here if needed)
 	clearScreen()
-	return domain.App{
-		Name:      projectForm.GetAppName(),
-		Framework: domain.FrameworkType(frameworkForm.GetChoice()),
-		DbType:    domain.DbType(databaseForm.GetChoice()),
-	}
+	return app, nil
 }
 
 func clearScreen() {
@@ -3,41 +3,373 @@ package utils
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"go/token"
 	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
 )
 
 type PostmanCollection struct {
-	Item []Item `json:"item"`
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+type Info struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema,omitempty"`
 }
 
 type Item struct {
 	Name    string  `json:"name"`
 	Item    []Item  `json:"item,omitempty"`
-	Request Request `json:"request,omitempty"`
+	Request Request `json:"request,omitzero"`
 }
 
 type Request struct {
-	Method string `json:"method"`
-	Url    Url    `json:"url,omitempty"`
+	Method string   `json:"method"`
+	Header []Header `json:"header,omitempty"`
+	Body   *Body    `json:"body,omitempty"`
+	Url    Url      `json:"url,omitzero"`
+}
+
+type Header struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type Body struct {
+	Mode    string       `json:"mode"`
+	Raw     string       `json:"raw"`
+	Options *BodyOptions `json:"options,omitempty"`
 }
 
+type BodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+type Variable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Url is the url of a request. Postman writes it either as a plain string
+// or as an object with the raw string and the parsed path segments.
 type Url struct {
-	Raw string `json:"raw"`
+	Raw      string     `json:"raw"`
+	Host     []string   `json:"host,omitempty"`
+	Path     []string   `json:"path,omitempty"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+func (u *Url) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+
+	type plain Url
+	return json.Unmarshal(data, (*plain)(u))
 }
 
-func ParseApi(input string) error {
+// ParseApi reads a Postman collection (v2.0 or v2.1) from input.
+func ParseApi(input string) (PostmanCollection, error) {
+	var ps PostmanCollection
+
 	file, err := parsePostmanFile(input)
 	if err != nil {
-		return errors.Join(errors.New("error when parse file"), err)
+		return ps, errors.Join(errors.New("error when parse file"), err)
 	}
 
-	var ps PostmanCollection
 	if err := json.Unmarshal(file, &ps); err != nil {
-		return err
+		return ps, errors.Join(errors.New("error when parse postman collection"), err)
 	}
-	return nil
+	return ps, nil
 }
 
 func parsePostmanFile(input string) ([]byte, error) {
 	return os.ReadFile(input)
 }
+
+// FileName returns the handler file name of the collection, e.g. media.go.
+func (pc PostmanCollection) FileName() string {
+	if name := identifier(pc.Info.Name); name != "" {
+		return toSnake(name) + ".go"
+	}
+
+	return "postman.go"
+}
+
+// RouteGroup is a Postman folder turned into a router group. Its routes and
+// subgroups are relative to Prefix.
+type RouteGroup struct {
+	// Var is the variable holding the group, empty for the root router.
+	Var    string
+	Prefix string
+	Routes []Route
+	Groups []RouteGroup
+}
+
+// Route is a request of a collection mapped to a handler.
+type Route struct {
+	Method string
+	// Path is relative to the prefix of its group, with parameters written as :name.
+	Path string
+	// FullPath is the absolute path of the route.
+	FullPath string
+	Handler  string
+}
+
+var (
+	postmanVariable = regexp.MustCompile(`^\{\{(.+)\}\}$`)
+	identifierWords = regexp.MustCompile(`[A-Za-z0-9]+`)
+)
+
+// Routes maps the requests of the collection to routes. Every folder becomes
+// a router group prefixed with the path its requests share; handler names
+// are built from the request names and made unique.
+func (pc PostmanCollection) Routes() RouteGroup {
+	names := map[string]int{}
+	vars := map[string]int{"app": 1, "h": 1}
+
+	var walk func(items []Item, prefix []string, folders []string, groupVar string) RouteGroup
+	walk = func(items []Item, prefix []string, folders []string, groupVar string) RouteGroup {
+		group := RouteGroup{Var: groupVar, Prefix: "/" + strings.Join(prefix, "/")}
+
+		for _, item := range items {
+			if len(item.Item) > 0 {
+				sub := commonPrefix(folderSegments(item.Item))
+				if len(sub) < len(prefix) {
+					sub = prefix
+				}
+
+				path := append(append([]string{}, folders...), item.Name)
+				name := lowerFirst(identifier(path...))
+				switch {
+				case name == "":
+					name = "group"
+				case unicode.IsDigit(rune(name[0])) || token.IsKeyword(name):
+					name += "Group"
+				}
+
+				child := walk(item.Item, sub, path, uniqueName(vars, name))
+				child.Prefix = "/" + strings.Join(sub[len(prefix):], "/")
+				group.Groups = append(group.Groups, child)
+				continue
+			}
+
+			if item.Request.Method == "" {
+				continue
+			}
+
+			method := strings.ToUpper(item.Request.Method)
+			segments := item.Request.Url.segments()
+
+			handler := identifier(item.Name)
+			if handler == "" {
+				handler = identifier(append([]string{strings.ToLower(method)}, segments...)...)
+			}
+			if handler == "" || unicode.IsDigit(rune(handler[0])) {
+				handler = "Handle" + handler
+			}
+
+			group.Routes = append(group.Routes, Route{
+				Method:   method,
+				Path:     "/" + strings.Join(segments[len(prefix):], "/"),
+				FullPath: "/" + strings.Join(segments, "/"),
+				Handler:  uniqueName(names, handler),
+			})
+		}
+
+		return group
+	}
+
+	return walk(pc.Item, nil, nil, "")
+}
+
+// AllRoutes returns the routes of the group and its subgroups.
+func (g RouteGroup) AllRoutes() []Route {
+	routes := append([]Route{}, g.Routes...)
+	for _, sub := range g.Groups {
+		routes = append(routes, sub.AllRoutes()...)
+	}
+
+	return routes
+}
+
+// Filter returns a copy of the group with only the routes keep accepts.
+// Groups left without routes are dropped.
+func (g RouteGroup) Filter(keep func(Route) bool) RouteGroup {
+	filtered := RouteGroup{Var: g.Var, Prefix: g.Prefix}
+	for _, route := range g.Routes {
+		if keep(route) {
+			filtered.Routes = append(filtered.Routes, route)
+		}
+	}
+
+	for _, sub := range g.Groups {
+		if sub = sub.Filter(keep); len(sub.Routes) > 0 || len(sub.Groups) > 0 {
+			filtered.Groups = append(filtered.Groups, sub)
+		}
+	}
+
+	return filtered
+}
+
+// Statements returns the statements registering the group on router.
+// Routers that can't group routes get every route on router with its full path.
+func (g RouteGroup) Statements(d Dialect, router string) []string {
+	var stmts []string
+	if g.Var != "" && d.Group != "" {
+		stmts = append(stmts, fmt.Sprintf("%s := %s.Group(%q)", g.Var, router, strings.TrimSuffix(g.Prefix, "/")))
+		router = g.Var
+	}
+
+	for _, route := range g.Routes {
+		path := route.Path
+		switch {
+		case d.Group == "":
+			path = route.FullPath
+		case g.Var != "" && path == "/":
+			path = ""
+		}
+		stmts = append(stmts, d.Route(router, route.Method, path, "h."+route.Handler))
+	}
+
+	for _, sub := range g.Groups {
+		stmts = append(stmts, sub.Statements(d, router)...)
+	}
+
+	return stmts
+}
+
+// segments returns the path of the url without host, with Postman variables
+// and :params both written as :name.
+func (u Url) segments() []string {
+	parts := u.Path
+	if len(parts) == 0 {
+		raw, _, _ := strings.Cut(u.Raw, "?")
+		if _, rest, ok := strings.Cut(raw, "://"); ok {
+			raw = rest
+		}
+
+		parts = strings.Split(raw, "/")
+		if len(parts) > 0 && !strings.HasPrefix(parts[0], ":") {
+			// the first element is the host, e.g. {{baseUrl}} or localhost:8080
+			parts = parts[1:]
+		}
+	}
+
+	segments := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		if match := postmanVariable.FindStringSubmatch(part); match != nil {
+			part = ":" + strings.TrimSpace(match[1])
+		}
+		segments = append(segments, part)
+	}
+
+	return segments
+}
+
+// folderSegments returns the paths of every request below items.
+func folderSegments(items []Item) [][]string {
+	var paths [][]string
+	for _, item := range items {
+		if len(item.Item) > 0 {
+			paths = append(paths, folderSegments(item.Item)...)
+			continue
+		}
+		if item.Request.Method != "" {
+			paths = append(paths, item.Request.Url.segments())
+		}
+	}
+
+	return paths
+}
+
+// commonPrefix returns the static leading segments shared by all paths.
+func commonPrefix(paths [][]string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	prefix := paths[0]
+	for _, path := range paths[1:] {
+		n := 0
+		for n < len(prefix) && n < len(path) && prefix[n] == path[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	for i, segment := range prefix {
+		if strings.HasPrefix(segment, ":") {
+			return prefix[:i]
+		}
+	}
+
+	return prefix
+}
+
+// identifier joins the words of parts into an exported Go identifier, e.g.
+// "get media list" -> GetMediaList.
+func identifier(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for _, word := range identifierWords.FindAllString(part, -1) {
+			if commonInitialisms[strings.ToLower(word)] {
+				b.WriteString(strings.ToUpper(word))
+				continue
+			}
+			b.WriteString(exportName(word))
+		}
+	}
+
+	return b.String()
+}
+
+// uniqueName returns name, or name with a number appended if it was taken.
+func uniqueName(taken map[string]int, name string) string {
+	if name == "" {
+		return ""
+	}
+
+	taken[name]++
+	if n := taken[name]; n > 1 {
+		return fmt.Sprintf("%s%d", name, n)
+	}
+	return name
+}
+
+// RenderHandlerStubs renders the handler methods of routes. Each stub
+// answers 501 Not Implemented until it is filled in.
+func RenderHandlerStubs(routes []Route, d Dialect) ([]byte, error) {
+	return renderGo(handlerStubsTemplate, map[string]any{"Routes": routes, "D": d})
+}
+
+var handlerStubsTemplate = template.Must(template.New("stubs").Funcs(templateFuncs).Parse(`package handler
+
+import (
+	"errors"
+	{{- if stdlib .D.HandlerImport}}
+	"{{.D.HandlerImport}}"
+	{{- else}}
+
+	"{{.D.HandlerImport}}"
+	{{- end}}
+)
+{{$d := .D}}{{range .Routes}}
+// {{.Handler}} handles {{.Method}} {{.FullPath}}.
+{{$d.Signature .Handler}} {
+	{{$d.Finish ($d.Call "errNotImplementedResponse" "errors.New(\"not implemented\")")}}
+}
+{{end}}`))
@@ -2,7 +2,12 @@ package utils
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/MH-KodaCore/goarm/domain"
 )
 
 func TestParseAPI(t *testing.T) {
@@ -25,4 +30,76 @@ func TestParseAPI(t *testing.T) {
 
 		t.Log(pc)
 	})
+
+	t.Run("map routes", func(t *testing.T) {
+		pc, err := ParseApi("../media.json")
+		if err != nil {
+			t.Fatal("error parse postman collection", err)
+		}
+
+		d, err := HandlerDialect(domain.FrameworkTypeGin)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{
+			`app.GET("/ping", h.Ping)`,
+			`media := app.Group("/api/v1/media")`,
+			`media.GET("", h.ListMedia)`,
+			`media.POST("", h.UploadMedia)`,
+			`media.GET("/:id", h.GetMedia)`,
+			`media.DELETE("/:id", h.DeleteMedia)`,
+			`mediaTags := media.Group("")`,
+			`mediaTags.GET("/:id/tags", h.ListMediaTags)`,
+			`mediaTags.POST("/:id/tags", h.AddMediaTag)`,
+		}
+		got := pc.Routes().Statements(d, "app")
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("unexpected routes:\n%s", strings.Join(got, "\n"))
+		}
+	})
+
+	t.Run("map routes to net/http patterns", func(t *testing.T) {
+		pc, err := ParseApi("../media.json")
+		if err != nil {
+			t.Fatal("error parse postman collection", err)
+		}
+
+		d, err := HandlerDialect(domain.FrameworkTypeStdlib)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{
+			`mux.HandleFunc("GET /ping", h.Ping)`,
+			`mux.HandleFunc("GET /api/v1/media", h.ListMedia)`,
+			`mux.HandleFunc("POST /api/v1/media", h.UploadMedia)`,
+			`mux.HandleFunc("GET /api/v1/media/{id}", h.GetMedia)`,
+			`mux.HandleFunc("DELETE /api/v1/media/{id}", h.DeleteMedia)`,
+			`mux.HandleFunc("GET /api/v1/media/{id}/tags", h.ListMediaTags)`,
+			`mux.HandleFunc("POST /api/v1/media/{id}/tags", h.AddMediaTag)`,
+		}
+		got := pc.Routes().Statements(d, d.RouterVar)
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("unexpected routes:\n%s", strings.Join(got, "\n"))
+		}
+
+		file := filepath.Join(t.TempDir(), "routes.go")
+		wrapped := `mux.HandleFunc("GET /auth/me", h.Auth(h.Me))`
+		src := "package handler\n\nfunc BindRoutes(mux *http.ServeMux, h *Handler) {\n" + strings.Join(append(want, wrapped), "\n") + "\n}\n"
+		if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		bound, err := BoundRoutes(file, "BindRoutes", d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bound) != len(want)+1 || bound[3].Method != "GET" || bound[3].Path != "/api/v1/media/:id" || bound[3].Handler != "GetMedia" {
+			t.Fatalf("unexpected bound routes: %+v", bound)
+		}
+		if me := bound[len(want)]; me.Path != "/auth/me" || me.Handler != "Me" {
+			t.Fatalf("expected the handler wrapped by the middleware, got %+v", me)
+		}
+	})
 }
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// ValidateModulePath reports why modulePath can't be used with go mod init.
+// Bare names like "billing" are accepted; paths whose first element looks like
+// a domain ("github.com/acme/billing") must also pass the stricter rules
+// applied to downloadable modules.
+func ValidateModulePath(modulePath string) error {
+	if strings.TrimSpace(modulePath) == "" {
+		return errors.New("module path cannot be empty")
+	}
+
+	if err := module.CheckImportPath(modulePath); err != nil {
+		return fmt.Errorf("invalid module path: %w", err)
+	}
+
+	first, _, _ := strings.Cut(modulePath, "/")
+	if strings.Contains(first, ".") {
+		if err := module.CheckPath(modulePath); err != nil {
+			return fmt.Errorf("invalid module path: %w", err)
+		}
+	}
+
+	return ValidateProjectName(ProjectDir(modulePath))
+}
+
+// ProjectDir returns the directory name for a module path: its last element,
+// ignoring a major version suffix (github.com/acme/billing/v2 -> billing).
+func ProjectDir(modulePath string) string {
+	prefix, _, ok := module.SplitPathVersion(modulePath)
+	if !ok || prefix == "" {
+		prefix = modulePath
+	}
+
+	return path.Base(prefix)
+}
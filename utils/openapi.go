@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI is an OpenAPI 3 document.
+type OpenAPI struct {
+	OpenAPI    string            `yaml:"openapi"`
+	Info       OpenAPIInfo       `yaml:"info"`
+	Servers    []OpenAPIServer   `yaml:"servers,omitempty"`
+	Paths      namedValues       `yaml:"paths"`
+	Components OpenAPIComponents `yaml:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type OpenAPIServer struct {
+	URL string `yaml:"url"`
+}
+
+type OpenAPIComponents struct {
+	Schemas namedValues `yaml:"schemas"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string             `yaml:"operationId"`
+	Tags        []string           `yaml:"tags,omitempty"`
+	Parameters  []OpenAPIParameter `yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIBody       `yaml:"requestBody,omitempty"`
+	Responses   namedValues        `yaml:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   *OpenAPISchema `yaml:"schema"`
+}
+
+type OpenAPIBody struct {
+	Description string                      `yaml:"description,omitempty"`
+	Required    bool                        `yaml:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `yaml:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `yaml:"schema"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string           `yaml:"$ref,omitempty"`
+	Type                 string           `yaml:"type,omitempty"`
+	Format               string           `yaml:"format,omitempty"`
+	Properties           namedValues      `yaml:"properties,omitempty"`
+	Items                *OpenAPISchema   `yaml:"items,omitempty"`
+	AdditionalProperties *OpenAPISchema   `yaml:"additionalProperties,omitempty"`
+	AllOf                []*OpenAPISchema `yaml:"allOf,omitempty"`
+}
+
+// namedValues is a YAML mapping that keeps the order its entries were added in.
+type namedValues []namedValue
+
+type namedValue struct {
+	name  string
+	value any
+}
+
+func (v namedValues) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, entry := range v {
+		var value yaml.Node
+		if err := value.Encode(entry.value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry.name}, &value)
+	}
+
+	return node, nil
+}
+
+// BuildOpenAPI describes api as an OpenAPI 3 document. Every response is
+// wrapped in the envelope of the API with the handler payload as its data.
+func BuildOpenAPI(title, serverURL string, api API) OpenAPI {
+	doc := OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    OpenAPIInfo{Title: title, Version: "0.1.0"},
+	}
+	if serverURL != "" {
+		doc.Servers = []OpenAPIServer{{URL: serverURL}}
+	}
+
+	components := openAPIComponents{names: map[string]string{}}
+	envelope := components.schema(api.Envelope)
+
+	paths := map[string]namedValues{}
+	var order []string
+	for _, endpoint := range api.Endpoints {
+		path, params := openAPIPath(endpoint.Path)
+		if _, ok := paths[path]; !ok {
+			order = append(order, path)
+		}
+
+		operation := OpenAPIOperation{
+			OperationID: endpoint.Handler,
+			Tags:        endpoint.Groups,
+			Parameters:  params,
+			Responses: namedValues{
+				{"200", OpenAPIBody{Description: "OK", Content: jsonContent(components.response(envelope, endpoint.Response))}},
+				{"default", OpenAPIBody{Description: "Error", Content: jsonContent(envelope)}},
+			},
+		}
+		if endpoint.Request != nil {
+			operation.RequestBody = &OpenAPIBody{Required: true, Content: jsonContent(components.schema(endpoint.Request))}
+		}
+
+		paths[path] = append(paths[path], namedValue{strings.ToLower(endpoint.Method), operation})
+	}
+
+	for _, path := range order {
+		doc.Paths = append(doc.Paths, namedValue{path, paths[path]})
+	}
+	doc.Components.Schemas = components.schemas
+
+	return doc
+}
+
+// openAPIComponents collects the named structs of the document.
+type openAPIComponents struct {
+	schemas namedValues
+	// names maps qualified Go names to component names.
+	names map[string]string
+}
+
+// schema converts s, adding named structs to the components and referencing them.
+func (c *openAPIComponents) schema(s *TypeSchema) *OpenAPISchema {
+	if s == nil {
+		return &OpenAPISchema{}
+	}
+
+	if s.Name != "" {
+		name, ok := c.names[s.Name]
+		if !ok {
+			name = c.componentName(s.Name)
+			c.names[s.Name] = name
+			if !s.Ref {
+				index := len(c.schemas)
+				c.schemas = append(c.schemas, namedValue{name, nil})
+				c.schemas[index].value = c.inline(s)
+			}
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+	}
+
+	return c.inline(s)
+}
+
+// inline converts s without referencing it.
+func (c *openAPIComponents) inline(s *TypeSchema) *OpenAPISchema {
+	schema := &OpenAPISchema{Format: s.Format}
+	switch s.Kind {
+	case "object":
+		schema.Type = "object"
+		for _, field := range s.Fields {
+			schema.Properties = append(schema.Properties, namedValue{field.Name, c.schema(field.Schema)})
+		}
+		if s.Values != nil {
+			schema.AdditionalProperties = c.schema(s.Values)
+		}
+	case "array":
+		schema.Type = "array"
+		schema.Items = c.schema(s.Items)
+	case "any":
+	default:
+		schema.Type = s.Kind
+	}
+
+	return schema
+}
+
+// response returns the envelope with data set to the payload schema.
+func (c *openAPIComponents) response(envelope *OpenAPISchema, payload *TypeSchema) *OpenAPISchema {
+	if payload == nil {
+		return envelope
+	}
+
+	data := &OpenAPISchema{Type: "object", Properties: namedValues{{"data", c.schema(payload)}}}
+	return &OpenAPISchema{AllOf: []*OpenAPISchema{envelope, data}}
+}
+
+// componentName turns a qualified Go name like domain.Product into a
+// component name, keeping the package only when two types share a name.
+func (c *openAPIComponents) componentName(qualified string) string {
+	_, name, _ := strings.Cut(qualified, ".")
+	taken := map[string]bool{}
+	for _, existing := range c.names {
+		taken[existing] = true
+	}
+	if name == "" || taken[name] {
+		return strings.ReplaceAll(qualified, ".", "_")
+	}
+
+	return name
+}
+
+func jsonContent(schema *OpenAPISchema) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{"application/json": {Schema: schema}}
+}
+
+// openAPIPath converts a router path like /products/:id to /products/{id}
+// and returns its path parameters.
+func openAPIPath(path string) (string, []OpenAPIParameter) {
+	segments := strings.Split(path, "/")
+	var params []OpenAPIParameter
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := strings.TrimSuffix(segment[1:], "?")
+			segments[i] = "{" + name + "}"
+			params = append(params, OpenAPIParameter{Name: name, In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"}})
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
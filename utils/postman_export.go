@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PostmanSchema is the schema of the collections written by ExportPostman.
+const PostmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// ExportPostman builds a Postman v2.1 collection of endpoints. Requests are
+// put in one folder per router group and use the {{baseUrl}} variable, set to
+// baseURL. Requests binding a known struct get an example JSON body.
+func ExportPostman(name, baseURL string, endpoints []Endpoint) PostmanCollection {
+	collection := PostmanCollection{
+		Info:     Info{Name: name, Schema: PostmanSchema},
+		Item:     []Item{},
+		Variable: []Variable{{Key: "baseUrl", Value: baseURL}},
+	}
+
+	for _, endpoint := range endpoints {
+		items := &collection.Item
+		for _, group := range endpoint.Groups {
+			items = folder(items, group)
+		}
+
+		*items = append(*items, Item{Name: endpoint.Handler, Request: postmanRequest(endpoint)})
+	}
+
+	return collection
+}
+
+// folder returns the items of the folder name in items, adding it if needed.
+func folder(items *[]Item, name string) *[]Item {
+	for i := range *items {
+		if (*items)[i].Name == name && (*items)[i].Request.Method == "" {
+			return &(*items)[i].Item
+		}
+	}
+
+	*items = append(*items, Item{Name: name})
+	return &(*items)[len(*items)-1].Item
+}
+
+// postmanRequest describes the request of an endpoint.
+func postmanRequest(endpoint Endpoint) Request {
+	request := Request{
+		Method: endpoint.Method,
+		Url: Url{
+			Raw:  "{{baseUrl}}" + endpoint.Path,
+			Host: []string{"{{baseUrl}}"},
+			Path: []string{},
+		},
+	}
+
+	for _, segment := range strings.Split(strings.Trim(endpoint.Path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		request.Url.Path = append(request.Url.Path, segment)
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			request.Url.Variable = append(request.Url.Variable, Variable{Key: segment[1:]})
+		}
+	}
+
+	if endpoint.Request == nil || endpoint.Method == "GET" || endpoint.Method == "HEAD" {
+		return request
+	}
+
+	example, err := json.MarshalIndent(endpoint.Request.Example(), "", "  ")
+	if err != nil {
+		return request
+	}
+
+	request.Header = []Header{{Key: "Content-Type", Value: "application/json"}}
+	request.Body = &Body{Mode: "raw", Raw: string(example), Options: &BodyOptions{}}
+	request.Body.Options.Raw.Language = "json"
+
+	return request
+}
@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+func TestExportPostman(t *testing.T) {
+	product := &TypeSchema{Name: "domain.Product", Kind: "object", Fields: []SchemaField{
+		{Name: "id", Schema: &TypeSchema{Kind: "integer"}},
+		{Name: "title", Schema: &TypeSchema{Kind: "string"}},
+	}}
+
+	endpoints := []Endpoint{
+		{BoundRoute: BoundRoute{Method: "GET", Path: "/ping", Handler: "Pong"}},
+		{BoundRoute: BoundRoute{Method: "POST", Path: "/api/products", Groups: []string{"api"}, Handler: "CreateProduct"}, Request: product},
+		{BoundRoute: BoundRoute{Method: "GET", Path: "/api/products/:id", Groups: []string{"api"}, Handler: "GetProduct"}, Response: product},
+	}
+
+	collection := ExportPostman("shop", "http://localhost:8080", endpoints)
+
+	if len(collection.Item) != 2 || collection.Item[1].Name != "api" || len(collection.Item[1].Item) != 2 {
+		t.Fatalf("expected Pong and an api folder with 2 requests, got %+v", collection.Item)
+	}
+
+	create := collection.Item[1].Item[0].Request
+	if create.Body == nil || create.Body.Raw != "{\n  \"id\": 0,\n  \"title\": \"\"\n}" {
+		t.Fatalf("unexpected body: %+v", create.Body)
+	}
+
+	get := collection.Item[1].Item[1].Request
+	if get.Body != nil || get.Url.Raw != "{{baseUrl}}/api/products/:id" || len(get.Url.Variable) != 1 || get.Url.Variable[0].Key != "id" {
+		t.Fatalf("unexpected request: %+v", get)
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -16,10 +17,10 @@ type ProjectNameForm struct {
 
 func newProjectNameForm() *ProjectNameForm {
 	ti := textinput.New()
-	ti.Placeholder = "Enter your project name"
+	ti.Placeholder = "Module path, e.g. github.com/acme/billing"
 	ti.Focus()
 
-	ti.CharLimit = 64
+	ti.CharLimit = 128
 	ti.Width = 38
 	ti.Prompt = ""
 
@@ -45,11 +46,11 @@ func (m *ProjectNameForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case "enter":
-			if m.isValidProjectName(m.input.Value()) {
+			if err := ValidateModulePath(m.input.Value()); err != nil {
+				m.errorMessage = err.Error()
+			} else {
 				m.errorMessage = ""
 				return m, tea.Quit
-			} else {
-				m.errorMessage = "Invalid project name. Use only letters, numbers, hyphens and underscores (no spaces)."
 			}
 		}
 	}
@@ -76,16 +77,18 @@ func (m *ProjectNameForm) GetAppName() string {
 	return m.input.Value()
 }
 
-// isValidProjectName validates that the project name is suitable for go mod init
-func (m *ProjectNameForm) isValidProjectName(name string) bool {
+var validProjectName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidateProjectName reports why name can't be used as the directory of a
+// project, or nil if it is suitable.
+func ValidateProjectName(name string) error {
 	if strings.TrimSpace(name) == "" {
-		return false
+		return errors.New("project name cannot be empty")
 	}
 
-	if strings.Contains(name, " ") {
-		return false
+	if !validProjectName.MatchString(name) {
+		return fmt.Errorf("invalid project name %q: use only letters, numbers, hyphens and underscores", name)
 	}
 
-	validName := regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
-	return validName.MatchString(name)
+	return nil
 }
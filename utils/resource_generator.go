@@ -0,0 +1,593 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+// Resource describes an entity generated end to end by `goarm generate resource`.
+type Resource struct {
+	// Name is the exported Go name, e.g. OrderItem.
+	Name   string
+	Fields []ResourceField
+}
+
+// ResourceField is a single field of a Resource.
+type ResourceField struct {
+	// Name is the Go field name, e.g. UserID.
+	Name string
+	// Type is the Go type, e.g. int64.
+	Type string
+	// Column is the json tag and database column, e.g. user_id.
+	Column string
+}
+
+// resourceTypes maps the types accepted in --fields to Go types.
+var resourceTypes = map[string]string{
+	"string":    "string",
+	"bool":      "bool",
+	"int":       "int",
+	"int32":     "int32",
+	"int64":     "int64",
+	"float32":   "float32",
+	"float64":   "float64",
+	"time":      "time.Time",
+	"time.Time": "time.Time",
+}
+
+// reservedResourceVars can't be used as the variable name of a resource as
+// they are taken by packages or variables in the generated code.
+var reservedResourceVars = map[string]bool{
+	"ctx": true, "err": true, "id": true, "h": true, "s": true, "r": true, "w": true,
+	"rows": true, "tag": true, "result": true, "domain": true, "context": true,
+	"errors": true, "strconv": true, "sql": true, "pgx": true, "time": true,
+}
+
+var (
+	validResourceName  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+	validResourceField = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	camelBoundary      = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+)
+
+// ParseResource builds a Resource from a name like "Product" and a field list
+// like "title:string price:int64". An "id" field is always generated.
+func ParseResource(name, fields string) (Resource, error) {
+	if !validResourceName.MatchString(name) {
+		return Resource{}, fmt.Errorf("invalid resource name %q: use letters and numbers only, starting with a letter", name)
+	}
+
+	resource := Resource{Name: exportName(name)}
+	if token.IsKeyword(resource.Var()) {
+		return Resource{}, fmt.Errorf("invalid resource name %q: it is a Go keyword", name)
+	}
+
+	seen := map[string]bool{"id": true}
+	for _, spec := range strings.Fields(fields) {
+		column, typ, ok := strings.Cut(spec, ":")
+		if !ok {
+			return Resource{}, fmt.Errorf("invalid field %q: expected name:type", spec)
+		}
+
+		column = toSnake(column)
+		if !validResourceField.MatchString(column) {
+			return Resource{}, fmt.Errorf("invalid field name %q", column)
+		}
+		if seen[column] {
+			return Resource{}, fmt.Errorf("duplicate field %q (id is generated)", column)
+		}
+		seen[column] = true
+
+		goType, ok := resourceTypes[typ]
+		if !ok {
+			return Resource{}, fmt.Errorf("unsupported type %q of field %q", typ, column)
+		}
+
+		resource.Fields = append(resource.Fields, ResourceField{
+			Name:   goName(column),
+			Type:   goType,
+			Column: column,
+		})
+	}
+
+	if len(resource.Fields) == 0 {
+		return Resource{}, fmt.Errorf("resource %s needs at least one field", resource.Name)
+	}
+
+	return resource, nil
+}
+
+// Var is the variable name of a single resource, e.g. orderItem.
+func (r Resource) Var() string {
+	name := lowerFirst(r.Name)
+	if reservedResourceVars[name] {
+		return "item"
+	}
+
+	return name
+}
+
+// Plural is the exported plural name, e.g. OrderItems.
+func (r Resource) Plural() string {
+	return pluralize(r.Name)
+}
+
+// Table is the database table name, e.g. order_items.
+func (r Resource) Table() string {
+	return toSnake(r.Plural())
+}
+
+// Path is the URL path of the collection, e.g. /order-items.
+func (r Resource) Path() string {
+	return "/" + strings.ReplaceAll(r.Table(), "_", "-")
+}
+
+// File is the file name used in every layer, e.g. order_item.go.
+func (r Resource) File() string {
+	return toSnake(r.Name) + ".go"
+}
+
+// Methods returns the method set shared by service.RepoInterface and
+// handler.ServiceInterface.
+func (r Resource) Methods() []string {
+	n, p := r.Name, r.Plural()
+	return []string{
+		fmt.Sprintf("Create%s(ctx context.Context, %s domain.%s) (domain.%s, error)", n, r.Var(), n, n),
+		fmt.Sprintf("Get%s(ctx context.Context, id int64) (domain.%s, error)", n, n),
+		fmt.Sprintf("List%s(ctx context.Context) ([]domain.%s, error)", p, n),
+		fmt.Sprintf("Update%s(ctx context.Context, %s domain.%s) error", n, r.Var(), n),
+		fmt.Sprintf("Delete%s(ctx context.Context, id int64) error", n),
+	}
+}
+
+// Routes returns the route registrations for BindRoutes.
+func (r Resource) Routes(d Dialect) []string {
+	item := r.Path() + "/:id"
+	return []string{
+		d.Route(d.RouterVar, "POST", r.Path(), "h.Create"+r.Name),
+		d.Route(d.RouterVar, "GET", r.Path(), "h.List"+r.Plural()),
+		d.Route(d.RouterVar, "GET", item, "h.Get"+r.Name),
+		d.Route(d.RouterVar, "PUT", item, "h.Update"+r.Name),
+		d.Route(d.RouterVar, "DELETE", item, "h.Delete"+r.Name),
+	}
+}
+
+// Columns returns the column list of the table without id.
+func (r Resource) Columns() string {
+	columns := make([]string, 0, len(r.Fields))
+	for _, f := range r.Fields {
+		columns = append(columns, f.Column)
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// ErrNotFoundSource is the internal/domain/errors.go file the generated
+// repositories use to report missing rows.
+const ErrNotFoundSource = `package domain
+
+import "errors"
+
+// ErrNotFound is returned when a requested resource doesn't exist.
+var ErrNotFound = errors.New("not found")
+`
+
+// RenderResourceDomain renders internal/domain/<resource>.go.
+func RenderResourceDomain(r Resource) ([]byte, error) {
+	return renderGo(resourceDomainTemplate, map[string]any{"R": r})
+}
+
+// RenderResourceRepo renders internal/repo/<resource>.go for the database
+// stored in the Repo field dbField.
+func RenderResourceRepo(module string, r Resource, dbField string, dbType domain.DbType) ([]byte, error) {
+	pgx := dbType == domain.DBTypePostgres
+	placeholder := func(i int) string {
+		if pgx {
+			return fmt.Sprintf("$%d", i)
+		}
+		return "?"
+	}
+
+	values := make([]string, 0, len(r.Fields))
+	sets := make([]string, 0, len(r.Fields))
+	for i, f := range r.Fields {
+		values = append(values, placeholder(i+1))
+		sets = append(sets, fmt.Sprintf("%s = %s", f.Column, placeholder(i+1)))
+	}
+
+	return renderGo(resourceRepoTemplate, map[string]any{
+		"R":       r,
+		"Module":  module,
+		"DB":      dbField,
+		"Pgx":     pgx,
+		"Values":  strings.Join(values, ", "),
+		"Sets":    strings.Join(sets, ", "),
+		"IDParam": placeholder(len(r.Fields) + 1),
+		"ID":      placeholder(1),
+	})
+}
+
+// RenderResourceService renders internal/service/<resource>.go.
+func RenderResourceService(module string, r Resource) ([]byte, error) {
+	return renderGo(resourceServiceTemplate, map[string]any{"R": r, "Module": module})
+}
+
+// RenderResourceHandler renders internal/handler/<resource>.go.
+func RenderResourceHandler(module string, r Resource, framework domain.FrameworkType) ([]byte, error) {
+	d, err := HandlerDialect(framework)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderGo(resourceHandlerTemplate, map[string]any{"R": r, "Module": module, "D": d})
+}
+
+// renderGo executes a template and gofmts the result.
+func renderGo(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go code: %w", err)
+	}
+
+	return src, nil
+}
+
+var templateFuncs = template.FuncMap{
+	// stdlib reports whether an import path belongs to the standard library.
+	"stdlib": func(importPath string) bool {
+		first, _, _ := strings.Cut(importPath, "/")
+		return !strings.Contains(first, ".")
+	},
+	"fields": func(r Resource, prefix string) string {
+		refs := make([]string, 0, len(r.Fields))
+		for _, f := range r.Fields {
+			refs = append(refs, prefix+f.Name)
+		}
+		return strings.Join(refs, ", ")
+	},
+	"hasTime": func(r Resource) bool {
+		for _, f := range r.Fields {
+			if f.Type == "time.Time" {
+				return true
+			}
+		}
+		return false
+	},
+	"lowerFirst": lowerFirst,
+}
+
+var resourceDomainTemplate = template.Must(template.New("domain").Funcs(templateFuncs).Parse(`package domain
+{{if hasTime .R}}
+import "time"
+{{end}}
+// {{.R.Name}} is stored in the {{.R.Table}} table.
+type {{.R.Name}} struct {
+	ID int64 ` + "`json:\"id\"`" + `
+{{- range .R.Fields}}
+	{{.Name}} {{.Type}} ` + "`json:\"{{.Column}}\"`" + `
+{{- end}}
+}
+`))
+
+var resourceRepoTemplate = template.Must(template.New("repo").Funcs(templateFuncs).Parse(`package repo
+
+import (
+	"context"
+{{- if .Pgx}}
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+{{- else}}
+	"database/sql"
+	"errors"
+{{- end}}
+
+	"{{.Module}}/internal/domain"
+)
+
+{{$r := .R}}{{$v := .R.Var}}
+func (r *Repo) Create{{$r.Name}}(ctx context.Context, {{$v}} domain.{{$r.Name}}) (domain.{{$r.Name}}, error) {
+{{- if .Pgx}}
+	err := r.{{.DB}}.QueryRow(ctx,
+		"INSERT INTO {{$r.Table}} ({{$r.Columns}}) VALUES ({{.Values}}) RETURNING id",
+		{{fields $r (printf "%s." $v)}},
+	).Scan(&{{$v}}.ID)
+
+	return {{$v}}, err
+{{- else}}
+	result, err := r.{{.DB}}.ExecContext(ctx,
+		"INSERT INTO {{$r.Table}} ({{$r.Columns}}) VALUES ({{.Values}})",
+		{{fields $r (printf "%s." $v)}},
+	)
+	if err != nil {
+		return {{$v}}, err
+	}
+
+	{{$v}}.ID, err = result.LastInsertId()
+	return {{$v}}, err
+{{- end}}
+}
+
+func (r *Repo) Get{{$r.Name}}(ctx context.Context, id int64) (domain.{{$r.Name}}, error) {
+	var {{$v}} domain.{{$r.Name}}
+	err := r.{{.DB}}.QueryRow{{if not .Pgx}}Context{{end}}(ctx,
+		"SELECT id, {{$r.Columns}} FROM {{$r.Table}} WHERE id = {{.ID}}", id,
+	).Scan(&{{$v}}.ID, {{fields $r (printf "&%s." $v)}})
+	if errors.Is(err, {{if .Pgx}}pgx{{else}}sql{{end}}.ErrNoRows) {
+		return {{$v}}, domain.ErrNotFound
+	}
+
+	return {{$v}}, err
+}
+
+func (r *Repo) List{{$r.Plural}}(ctx context.Context) ([]domain.{{$r.Name}}, error) {
+	rows, err := r.{{.DB}}.Query{{if not .Pgx}}Context{{end}}(ctx, "SELECT id, {{$r.Columns}} FROM {{$r.Table}} ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	{{lowerFirst $r.Plural}} := []domain.{{$r.Name}}{}
+	for rows.Next() {
+		var {{$v}} domain.{{$r.Name}}
+		if err := rows.Scan(&{{$v}}.ID, {{fields $r (printf "&%s." $v)}}); err != nil {
+			return nil, err
+		}
+		{{lowerFirst $r.Plural}} = append({{lowerFirst $r.Plural}}, {{$v}})
+	}
+
+	return {{lowerFirst $r.Plural}}, rows.Err()
+}
+
+func (r *Repo) Update{{$r.Name}}(ctx context.Context, {{$v}} domain.{{$r.Name}}) error {
+	{{if .Pgx}}tag{{else}}result{{end}}, err := r.{{.DB}}.Exec{{if not .Pgx}}Context{{end}}(ctx,
+		"UPDATE {{$r.Table}} SET {{.Sets}} WHERE id = {{.IDParam}}",
+		{{fields $r (printf "%s." $v)}}, {{$v}}.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return {{if .Pgx}}checkAffected(tag.RowsAffected(), nil){{else}}checkAffected(result.RowsAffected()){{end}}
+}
+
+func (r *Repo) Delete{{$r.Name}}(ctx context.Context, id int64) error {
+	{{if .Pgx}}tag{{else}}result{{end}}, err := r.{{.DB}}.Exec{{if not .Pgx}}Context{{end}}(ctx, "DELETE FROM {{$r.Table}} WHERE id = {{.ID}}", id)
+	if err != nil {
+		return err
+	}
+
+	return {{if .Pgx}}checkAffected(tag.RowsAffected(), nil){{else}}checkAffected(result.RowsAffected()){{end}}
+}
+`))
+
+// RenderRepoHelpers renders internal/repo/helpers.go shared by generated
+// repositories.
+func RenderRepoHelpers(module string) ([]byte, error) {
+	return format.Source([]byte(fmt.Sprintf(repoHelpersSource, module)))
+}
+
+const repoHelpersSource = `package repo
+
+import "%s/internal/domain"
+
+// checkAffected turns an update or delete that matched no rows into domain.ErrNotFound.
+func checkAffected(rows int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+`
+
+var resourceServiceTemplate = template.Must(template.New("service").Funcs(templateFuncs).Parse(`package service
+
+import (
+	"context"
+
+	"{{.Module}}/internal/domain"
+)
+
+{{$r := .R}}{{$v := .R.Var}}
+func (s *Service) Create{{$r.Name}}(ctx context.Context, {{$v}} domain.{{$r.Name}}) (domain.{{$r.Name}}, error) {
+	return s.repo.Create{{$r.Name}}(ctx, {{$v}})
+}
+
+func (s *Service) Get{{$r.Name}}(ctx context.Context, id int64) (domain.{{$r.Name}}, error) {
+	return s.repo.Get{{$r.Name}}(ctx, id)
+}
+
+func (s *Service) List{{$r.Plural}}(ctx context.Context) ([]domain.{{$r.Name}}, error) {
+	return s.repo.List{{$r.Plural}}(ctx)
+}
+
+func (s *Service) Update{{$r.Name}}(ctx context.Context, {{$v}} domain.{{$r.Name}}) error {
+	return s.repo.Update{{$r.Name}}(ctx, {{$v}})
+}
+
+func (s *Service) Delete{{$r.Name}}(ctx context.Context, id int64) error {
+	return s.repo.Delete{{$r.Name}}(ctx, id)
+}
+`))
+
+var resourceHandlerTemplate = template.Must(template.New("handler").Funcs(templateFuncs).Parse(`package handler
+
+import (
+	{{- if .D.BindImport}}
+	"{{.D.BindImport}}"
+	{{- end}}
+	"errors"
+	"strconv"
+	{{- if stdlib .D.HandlerImport}}
+	"{{.D.HandlerImport}}"
+	{{- else}}
+
+	"{{.D.HandlerImport}}"
+	{{- end}}
+
+	"{{.Module}}/internal/domain"
+)
+
+{{$r := .R}}{{$v := .R.Var}}{{$d := .D}}
+{{$d.Signature (printf "Create%s" $r.Name)}} {
+	var {{$v}} domain.{{$r.Name}}
+	if err := {{printf $d.Bind (printf "&%s" $v)}}; err != nil {
+		{{$d.Respond ($d.Call "errBadResponse" "err")}}
+	}
+
+	{{$v}}, err := h.service.Create{{$r.Name}}({{$d.Context}}, {{$v}})
+	if err != nil {
+		{{$d.Respond ($d.Call "errInternalServerErrorResponse" "err")}}
+	}
+
+	{{$d.Finish ($d.Call "successResponse" $v)}}
+}
+
+{{$d.Signature (printf "Get%s" $r.Name)}} {
+	id, err := strconv.ParseInt({{printf $d.Param "id"}}, 10, 64)
+	if err != nil {
+		{{$d.Respond ($d.Call "errBadResponse" "err")}}
+	}
+
+	{{$v}}, err := h.service.Get{{$r.Name}}({{$d.Context}}, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		{{$d.Respond ($d.Call "errNotFoundResponse" "err")}}
+	}
+	if err != nil {
+		{{$d.Respond ($d.Call "errInternalServerErrorResponse" "err")}}
+	}
+
+	{{$d.Finish ($d.Call "successResponse" $v)}}
+}
+
+{{$d.Signature (printf "List%s" $r.Plural)}} {
+	{{lowerFirst $r.Plural}}, err := h.service.List{{$r.Plural}}({{$d.Context}})
+	if err != nil {
+		{{$d.Respond ($d.Call "errInternalServerErrorResponse" "err")}}
+	}
+
+	{{$d.Finish ($d.Call "successResponse" (lowerFirst $r.Plural))}}
+}
+
+{{$d.Signature (printf "Update%s" $r.Name)}} {
+	id, err := strconv.ParseInt({{printf $d.Param "id"}}, 10, 64)
+	if err != nil {
+		{{$d.Respond ($d.Call "errBadResponse" "err")}}
+	}
+
+	var {{$v}} domain.{{$r.Name}}
+	if err := {{printf $d.Bind (printf "&%s" $v)}}; err != nil {
+		{{$d.Respond ($d.Call "errBadResponse" "err")}}
+	}
+	{{$v}}.ID = id
+
+	err = h.service.Update{{$r.Name}}({{$d.Context}}, {{$v}})
+	if errors.Is(err, domain.ErrNotFound) {
+		{{$d.Respond ($d.Call "errNotFoundResponse" "err")}}
+	}
+	if err != nil {
+		{{$d.Respond ($d.Call "errInternalServerErrorResponse" "err")}}
+	}
+
+	{{$d.Finish ($d.Call "successResponse" $v)}}
+}
+
+{{$d.Signature (printf "Delete%s" $r.Name)}} {
+	id, err := strconv.ParseInt({{printf $d.Param "id"}}, 10, 64)
+	if err != nil {
+		{{$d.Respond ($d.Call "errBadResponse" "err")}}
+	}
+
+	err = h.service.Delete{{$r.Name}}({{$d.Context}}, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		{{$d.Respond ($d.Call "errNotFoundResponse" "err")}}
+	}
+	if err != nil {
+		{{$d.Respond ($d.Call "errInternalServerErrorResponse" "err")}}
+	}
+
+	{{$d.Finish ($d.Call "successResponse" "nil")}}
+}
+`))
+
+// exportName upper-cases the first letter of name.
+func exportName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// lowerFirst lower-cases the leading upper case run of name, so "OrderItems"
+// becomes "orderItems" and "URLs" becomes "urls".
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
+
+// toSnake converts CamelCase to snake_case.
+func toSnake(name string) string {
+	return strings.ToLower(camelBoundary.ReplaceAllString(name, "${1}_${2}"))
+}
+
+// commonInitialisms are written in upper case in Go names.
+var commonInitialisms = map[string]bool{
+	"id": true, "url": true, "uri": true, "api": true, "http": true, "json": true,
+	"ip": true, "sql": true, "uuid": true, "html": true, "xml": true,
+}
+
+// goName converts a snake_case column to a Go field name, e.g. user_id -> UserID.
+func goName(column string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(column, "_") {
+		if part == "" {
+			continue
+		}
+		if commonInitialisms[part] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		b.WriteString(exportName(part))
+	}
+
+	return b.String()
+}
+
+// pluralize returns a simple English plural of a CamelCase name.
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+func TestRenderResource(t *testing.T) {
+	const module = "github.com/acme/shop"
+
+	r, err := ParseResource("Product", "title:string price:int64")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	t.Run("repo", func(t *testing.T) {
+		tests := []struct {
+			dbType domain.DbType
+			want   []string
+		}{
+			{domain.DBTypePostgres, []string{
+				`"github.com/jackc/pgx/v5"`,
+				"r.db.QueryRow(ctx,",
+				"INSERT INTO products (title, price) VALUES ($1, $2) RETURNING id",
+				"UPDATE products SET title = $1, price = $2 WHERE id = $3",
+				"DELETE FROM products WHERE id = $1",
+				"errors.Is(err, pgx.ErrNoRows)",
+				"checkAffected(tag.RowsAffected(), nil)",
+			}},
+			{domain.DBTypeMySQL, []string{
+				`"database/sql"`,
+				"r.db.QueryRowContext(ctx,",
+				"INSERT INTO products (title, price) VALUES (?, ?)",
+				"UPDATE products SET title = ?, price = ? WHERE id = ?",
+				"DELETE FROM products WHERE id = ?",
+				"result.LastInsertId()",
+				"errors.Is(err, sql.ErrNoRows)",
+				"checkAffected(result.RowsAffected())",
+			}},
+			{domain.DBTypeSQLite, []string{
+				`"database/sql"`,
+				"r.db.ExecContext(ctx,",
+				"INSERT INTO products (title, price) VALUES (?, ?)",
+				"SELECT id, title, price FROM products WHERE id = ?",
+				"result.LastInsertId()",
+			}},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.dbType), func(t *testing.T) {
+				content, err := RenderResourceRepo(module, r, "db", tt.dbType)
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+
+				checkRendered(t, content, tt.want)
+				if tt.dbType != domain.DBTypePostgres && strings.Contains(string(content), "$1") {
+					t.Fatalf("unexpected pgx placeholder:\n%s", content)
+				}
+			})
+		}
+	})
+
+	t.Run("service", func(t *testing.T) {
+		content, err := RenderResourceService(module, r)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		checkRendered(t, content, []string{
+			`"github.com/acme/shop/internal/domain"`,
+			"return s.repo.CreateProduct(ctx, product)",
+			"return s.repo.GetProduct(ctx, id)",
+			"return s.repo.ListProducts(ctx)",
+			"return s.repo.UpdateProduct(ctx, product)",
+			"return s.repo.DeleteProduct(ctx, id)",
+		})
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		tests := []struct {
+			framework domain.FrameworkType
+			want      []string
+		}{
+			{domain.FrameworkTypeGin, []string{
+				`"github.com/gin-gonic/gin"`,
+				"func (h *Handler) CreateProduct(ctx *gin.Context) {",
+				"ctx.ShouldBindJSON(&product)",
+				`strconv.ParseInt(ctx.Param("id"), 10, 64)`,
+				"h.service.GetProduct(ctx, id)",
+			}},
+			{domain.FrameworkTypeFiber, []string{
+				`"github.com/gofiber/fiber/v2"`,
+				"func (h *Handler) CreateProduct(ctx *fiber.Ctx) error {",
+				"ctx.BodyParser(&product)",
+				`strconv.ParseInt(ctx.Params("id"), 10, 64)`,
+				"h.service.GetProduct(ctx.UserContext(), id)",
+			}},
+			{domain.FrameworkTypeEcho, []string{
+				`"github.com/labstack/echo/v4"`,
+				"func (h *Handler) CreateProduct(ctx echo.Context) error {",
+				"ctx.Bind(&product)",
+				`strconv.ParseInt(ctx.Param("id"), 10, 64)`,
+				"h.service.GetProduct(ctx.Request().Context(), id)",
+			}},
+			{domain.FrameworkTypeChi, []string{
+				"func (h *Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {",
+				"json.NewDecoder(r.Body).Decode(&product)",
+				`strconv.ParseInt(r.PathValue("id"), 10, 64)`,
+				"h.service.GetProduct(r.Context(), id)",
+			}},
+			{domain.FrameworkTypeStdlib, []string{
+				"func (h *Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {",
+				"json.NewDecoder(r.Body).Decode(&product)",
+				`strconv.ParseInt(r.PathValue("id"), 10, 64)`,
+				"h.service.GetProduct(r.Context(), id)",
+			}},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.framework), func(t *testing.T) {
+				content, err := RenderResourceHandler(module, r, tt.framework)
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+
+				checkRendered(t, content, tt.want)
+				for _, method := range r.Methods() {
+					name, _, _ := strings.Cut(method, "(")
+					if !strings.Contains(string(content), "func (h *Handler) "+name+"(") {
+						t.Fatalf("missing handler %s:\n%s", name, content)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("unsupported framework", func(t *testing.T) {
+		if _, err := RenderResourceHandler(module, r, domain.FrameworkType("beego")); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+// checkRendered fails unless content is valid Go containing every string of want.
+func checkRendered(t *testing.T, content []byte, want []string) {
+	t.Helper()
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "", content, 0); err != nil {
+		t.Fatalf("invalid Go: %v\n%s", err, content)
+	}
+	for _, w := range want {
+		if !strings.Contains(string(content), w) {
+			t.Fatalf("missing %q:\n%s", w, content)
+		}
+	}
+}
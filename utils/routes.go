@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// BoundRoute is a route registered in a BindRoutes like function.
+type BoundRoute struct {
+	Method string
+	// Path is the absolute path of the route, including the group prefixes.
+	Path string
+	// Groups are the variables of the nested router groups the route is
+	// registered on, outermost first, e.g. [api media] for media.GET(...).
+	Groups []string
+	// Handler is the registered handler, e.g. "Pong" for h.Pong.
+	Handler string
+}
+
+// BoundRoutes statically reads the routes funcName in filePath registers on
+// its first parameter, following groups created with `x := app.Group("/p")`.
+func BoundRoutes(filePath, funcName string, d Dialect) ([]BoundRoute, error) {
+	node, err := parser.ParseFile(token.NewFileSet(), filePath, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	var funcDecl *ast.FuncDecl
+	for _, decl := range node.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == funcName && fn.Body != nil {
+			funcDecl = fn
+		}
+	}
+	if funcDecl == nil {
+		return nil, fmt.Errorf("function %q not found in %s", funcName, filePath)
+	}
+
+	params := funcDecl.Type.Params.List
+	if len(params) == 0 || len(params[0].Names) == 0 {
+		return nil, fmt.Errorf("function %q has no router parameter", funcName)
+	}
+
+	methods := map[string]string{}
+	for method, name := range d.Methods {
+		methods[name] = method
+	}
+
+	// prefixes maps router and group variables to their path prefix and
+	// groups to the variables of their parent groups
+	prefixes := map[string]string{params[0].Names[0].Name: ""}
+	groups := map[string][]string{}
+	receiver := func(call *ast.CallExpr) (router, method, path string, ok bool) {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || len(call.Args) == 0 {
+			return "", "", "", false
+		}
+		x, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return "", "", "", false
+		}
+		if _, ok := prefixes[x.Name]; !ok {
+			return "", "", "", false
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return "", "", "", false
+		}
+		path, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", "", "", false
+		}
+
+		return x.Name, sel.Sel.Name, path, true
+	}
+
+	var routes []BoundRoute
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+				return true
+			}
+			lhs, ok := stmt.Lhs[0].(*ast.Ident)
+			call, isCall := stmt.Rhs[0].(*ast.CallExpr)
+			if !ok || !isCall {
+				return true
+			}
+			if x, method, path, ok := receiver(call); ok && method == "Group" {
+				prefixes[lhs.Name] = joinRoutePath(prefixes[x], path)
+				groups[lhs.Name] = append(append([]string{}, groups[x]...), lhs.Name)
+			}
+		case *ast.CallExpr:
+			x, name, path, ok := receiver(stmt)
+			method, isRoute := methods[name]
+			if d.Patterns {
+				method, path, isRoute = patternRoute(path)
+				isRoute = isRoute && (name == "HandleFunc" || name == "Handle")
+			}
+			if d.BraceParams {
+				path = colonParams(path)
+			}
+			if !ok || !isRoute || len(stmt.Args) < 2 {
+				return true
+			}
+
+			// Middlewares wrapping the handler, e.g. h.Auth(h.Me), are skipped
+			last := stmt.Args[len(stmt.Args)-1]
+			for {
+				call, ok := last.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 {
+					break
+				}
+				last = call.Args[len(call.Args)-1]
+			}
+
+			handler := nodeString(last)
+			if sel, ok := last.(*ast.SelectorExpr); ok {
+				handler = sel.Sel.Name
+			}
+
+			routes = append(routes, BoundRoute{
+				Method:  method,
+				Path:    joinRoutePath(prefixes[x], path),
+				Groups:  groups[x],
+				Handler: handler,
+			})
+		}
+		return true
+	})
+
+	return routes, nil
+}
+
+// patternRoute splits a net/http pattern like "GET example.com/items/{id}"
+// into its method and router path. Patterns without a method match every
+// method and aren't reported.
+func patternRoute(pattern string) (method, path string, ok bool) {
+	method, rest, ok := strings.Cut(strings.TrimSpace(pattern), " ")
+	if !ok {
+		return "", "", false
+	}
+
+	rest = strings.TrimSpace(rest)
+	index := strings.Index(rest, "/")
+	if index < 0 {
+		return "", "", false
+	}
+
+	return method, rest[index:], true
+}
+
+// joinRoutePath joins a group prefix and a path the way routers do.
+func joinRoutePath(prefix, path string) string {
+	joined := strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+	if joined != "/" {
+		joined = strings.TrimSuffix(joined, "/")
+	}
+
+	return joined
+}
+
+// TypeMethods returns the names of the methods declared on typeName in the
+// Go files of dir.
+func TypeMethods(dir, typeName string) (map[string]bool, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := map[string]bool{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && receiverName(fn) == typeName {
+				methods[fn.Name.Name] = true
+			}
+		}
+	}
+
+	return methods, nil
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+const scfListHeight = 6
+
+// CacheItem represents a cache option.
+type CacheItem string
+
+func (i CacheItem) FilterValue() string { return "" }
+
+// Delegate rendering each item
+type scfItemDelegate struct{}
+
+func (d scfItemDelegate) Height() int                             { return 1 }
+func (d scfItemDelegate) Spacing() int                            { return 0 }
+func (d scfItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d scfItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(CacheItem)
+	if !ok {
+		return
+	}
+
+	str := fmt.Sprintf("%d. %s", index+1, i)
+
+	if index == m.Index() {
+		fmt.Fprint(w, scfSelectedItemStyle.Render("➤ "+str))
+		return
+	}
+
+	fmt.Fprint(w, scfItemStyle.Render("  "+str))
+}
+
+type CacheSelectForm struct {
+	list     list.Model
+	choice   string
+	quitting bool
+}
+
+func (m *CacheSelectForm) Init() tea.Cmd {
+	return nil
+}
+
+func (m *CacheSelectForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "enter":
+			if i, ok := m.list.SelectedItem().(CacheItem); ok {
+				m.choice = string(i)
+			}
+
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m *CacheSelectForm) View() string {
+	title := titleStyle.Render("🚀 Choose cache")
+	return fmt.Sprintf("%s \n%s", title, m.list.View())
+}
+
+func (m CacheSelectForm) GetChoice() string {
+	return m.choice
+}
+
+func newCacheSelectForm() CacheSelectForm {
+	items := make([]list.Item, len(domain.SupportedCacheTypes))
+	for index, _ := range domain.SupportedCacheTypes {
+		items[index] = CacheItem(domain.SupportedCacheTypes[index])
+	}
+
+	const defaultWidth = 40
+
+	l := list.New(items, scfItemDelegate{}, defaultWidth, scfListHeight)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowTitle(false)
+
+	return CacheSelectForm{
+		list: l,
+	}
+}
@@ -6,10 +6,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/orayew2002/goarm/domain"
+
+	"github.com/MH-KodaCore/goarm/domain"
 )
 
-const sdfListHeight = 6
+const sdfListHeight = 7
 
 // DatabaseItem represents a database option.
 type DatabaseItem string
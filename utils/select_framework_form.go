@@ -10,7 +10,7 @@ import (
 	"github.com/MH-KodaCore/goarm/domain"
 )
 
-const sffListHeight = 4
+const sffListHeight = 8
 
 // FrameworkItem represents a framework option.
 type FrameworkItem string
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+const sofListHeight = 6
+
+// ORMItem represents a data access option.
+type ORMItem string
+
+func (i ORMItem) FilterValue() string { return "" }
+
+// Delegate rendering each item
+type sofItemDelegate struct{}
+
+func (d sofItemDelegate) Height() int                             { return 1 }
+func (d sofItemDelegate) Spacing() int                            { return 0 }
+func (d sofItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d sofItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(ORMItem)
+	if !ok {
+		return
+	}
+
+	str := fmt.Sprintf("%d. %s", index+1, i)
+
+	if index == m.Index() {
+		fmt.Fprint(w, sofSelectedItemStyle.Render("➤ "+str))
+		return
+	}
+
+	fmt.Fprint(w, sofItemStyle.Render("  "+str))
+}
+
+type ORMSelectForm struct {
+	list     list.Model
+	choice   string
+	quitting bool
+}
+
+func (m *ORMSelectForm) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ORMSelectForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "enter":
+			if i, ok := m.list.SelectedItem().(ORMItem); ok {
+				m.choice = string(i)
+			}
+
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m *ORMSelectForm) View() string {
+	title := titleStyle.Render("🚀 Choose data access")
+	return fmt.Sprintf("%s \n%s", title, m.list.View())
+}
+
+func (m ORMSelectForm) GetChoice() string {
+	return m.choice
+}
+
+func newORMSelectForm() ORMSelectForm {
+	items := make([]list.Item, len(domain.SupportedDataAccess))
+	for index, _ := range domain.SupportedDataAccess {
+		items[index] = ORMItem(domain.SupportedDataAccess[index])
+	}
+
+	const defaultWidth = 40
+
+	l := list.New(items, sofItemDelegate{}, defaultWidth, sofListHeight)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowTitle(false)
+
+	return ORMSelectForm{
+		list: l,
+	}
+}
@@ -0,0 +1,285 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+// Spec is the schema of a goarm.yaml file. It describes a project so the same
+// scaffold can be reproduced with `goarm new -f goarm.yaml`.
+type Spec struct {
+	Module    string   `yaml:"module"`
+	Framework string   `yaml:"framework"`
+	Database  string   `yaml:"database"`
+	ORM       string   `yaml:"orm"`
+	Cache     string   `yaml:"cache"`
+	Features  []string `yaml:"features"`
+	App       SpecApp  `yaml:"app"`
+	Envs      []string `yaml:"envs"`
+}
+
+// SpecApp holds the server settings written to etc/<env>.yaml.
+type SpecApp struct {
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+// LoadSpec reads and validates the spec file at path.
+func LoadSpec(path string) (domain.App, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.App{}, fmt.Errorf("can't read spec: %w", err)
+	}
+
+	return ParseSpec(path, data)
+}
+
+// ParseSpec decodes and validates a spec. Every error is prefixed with
+// "<name>:<line>:" so it points at the offending entry.
+func ParseSpec(name string, data []byte) (domain.App, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return domain.App{}, specYAMLError(name, err)
+	}
+
+	if len(root.Content) == 0 {
+		return domain.App{}, fmt.Errorf("%s: spec is empty", name)
+	}
+
+	var spec Spec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return domain.App{}, specYAMLError(name, err)
+	}
+
+	v := specValidator{name: name, root: root.Content[0]}
+	app := v.validate(spec)
+	if len(v.errs) > 0 {
+		return domain.App{}, errors.Join(v.errs...)
+	}
+
+	return app, nil
+}
+
+var (
+	validEnvName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+	yamlLineErr  = regexp.MustCompile(`^line (\d+): (.*)$`)
+	yamlFieldErr = regexp.MustCompile(`field (\S+) not found in type \S+$`)
+)
+
+// ValidatePort reports whether port is a valid TCP port number.
+func ValidatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid port %q: must be a number between 1 and 65535", port)
+	}
+
+	return nil
+}
+
+// ValidateEnvName reports whether env can be used as an etc/<env>.yaml name.
+func ValidateEnvName(env string) error {
+	if !validEnvName.MatchString(env) {
+		return fmt.Errorf("invalid env name %q: use only lowercase letters, numbers, hyphens and underscores", env)
+	}
+
+	return nil
+}
+
+type specValidator struct {
+	name string
+	root *yaml.Node
+	errs []error
+}
+
+func (v *specValidator) validate(spec Spec) domain.App {
+	var app domain.App
+
+	if v.required("module", spec.Module) {
+		if err := ValidateModulePath(spec.Module); err != nil {
+			v.fail(v.line("module"), err)
+		}
+		app.Module = spec.Module
+		app.Name = ProjectDir(spec.Module)
+	}
+
+	if v.required("framework", spec.Framework) {
+		framework, err := domain.ParseFrameworkType(spec.Framework)
+		if err != nil {
+			v.fail(v.line("framework"), err)
+		}
+		app.Framework = framework
+	}
+
+	if v.required("database", spec.Database) {
+		dbType, err := domain.ParseDbType(spec.Database)
+		if err != nil {
+			v.fail(v.line("database"), err)
+		}
+		app.DbType = dbType
+	}
+
+	if spec.ORM != "" {
+		dataAccess, err := domain.ParseDataAccess(spec.ORM)
+		if err != nil {
+			v.fail(v.line("orm"), err)
+		}
+		app.DataAccess = dataAccess
+	}
+
+	if spec.Cache != "" {
+		cache, err := domain.ParseCacheType(spec.Cache)
+		if err != nil {
+			v.fail(v.line("cache"), err)
+		}
+		app.Cache = cache
+	}
+
+	// An omitted list means "use the defaults", an empty one means "none".
+	if node := v.node("features"); node != nil {
+		app.Features = []domain.Feature{}
+		seen := map[domain.Feature]bool{}
+		for i, value := range spec.Features {
+			feature, err := domain.ParseFeature(value)
+			switch {
+			case err != nil:
+				v.fail(v.itemLine(node, i), err)
+			case seen[feature]:
+				v.fail(v.itemLine(node, i), fmt.Errorf("duplicate feature %q", value))
+			default:
+				seen[feature] = true
+				app.Features = append(app.Features, feature)
+			}
+		}
+	}
+
+	if err := app.CheckFeatures(); err != nil {
+		key := "orm"
+		if app.DbType != "" && !app.DbType.IsSQL() {
+			key = "database"
+		}
+		v.fail(v.line(key), err)
+	}
+
+	app.Host = spec.App.Host
+	if spec.App.Port != "" {
+		if err := ValidatePort(spec.App.Port); err != nil {
+			v.fail(v.line("app", "port"), err)
+		}
+		app.Port = spec.App.Port
+	}
+
+	if node := v.node("envs"); node != nil && len(spec.Envs) == 0 {
+		v.fail(node.Line, errors.New("envs must list at least one environment"))
+	}
+	seen := map[string]bool{}
+	for i, env := range spec.Envs {
+		switch err := ValidateEnvName(env); {
+		case err != nil:
+			v.fail(v.itemLine(v.node("envs"), i), err)
+		case seen[env]:
+			v.fail(v.itemLine(v.node("envs"), i), fmt.Errorf("duplicate env %q", env))
+		default:
+			seen[env] = true
+			app.Envs = append(app.Envs, env)
+		}
+	}
+
+	return app
+}
+
+// required records an error when a required key is missing or empty.
+func (v *specValidator) required(key, value string) bool {
+	if strings.TrimSpace(value) != "" {
+		return true
+	}
+
+	line := v.line(key)
+	if line == 0 {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s is required", v.name, key))
+		return false
+	}
+
+	v.fail(line, fmt.Errorf("%s must not be empty", key))
+	return false
+}
+
+func (v *specValidator) fail(line int, err error) {
+	v.errs = append(v.errs, fmt.Errorf("%s:%d: %w", v.name, line, err))
+}
+
+// node returns the value node found by following keys from the root mapping.
+func (v *specValidator) node(keys ...string) *yaml.Node {
+	node := v.root
+	for _, key := range keys {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+
+	return node
+}
+
+// line returns the line of the value at keys, or 0 when it is missing.
+func (v *specValidator) line(keys ...string) int {
+	if node := v.node(keys...); node != nil {
+		return node.Line
+	}
+
+	return 0
+}
+
+// itemLine returns the line of the i-th entry of a sequence node.
+func (v *specValidator) itemLine(node *yaml.Node, i int) int {
+	if node == nil {
+		return 0
+	}
+	if node.Kind == yaml.SequenceNode && i < len(node.Content) {
+		return node.Content[i].Line
+	}
+
+	return node.Line
+}
+
+// specYAMLError rewrites yaml decoding errors to the "<name>:<line>: msg" format.
+func specYAMLError(name string, err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		if m := yamlLineErr.FindStringSubmatch(msg); m != nil {
+			return fmt.Errorf("%s:%s: %s", name, m[1], m[2])
+		}
+		return fmt.Errorf("%s: %s", name, msg)
+	}
+
+	errs := make([]error, 0, len(typeErr.Errors))
+	for _, msg := range typeErr.Errors {
+		msg = yamlFieldErr.ReplaceAllString(msg, `unknown field "$1"`)
+		if m := yamlLineErr.FindStringSubmatch(msg); m != nil {
+			errs = append(errs, fmt.Errorf("%s:%s: %s", name, m[1], m[2]))
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %s", name, msg))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MH-KodaCore/goarm/domain"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Run("valid spec", func(t *testing.T) {
+		spec := `
+module: github.com/acme/billing
+framework: gin
+database: postgres
+orm: gorm
+cache: redis
+features: [docker]
+app:
+  port: 9090
+envs: [dev, prod]
+`
+		app, err := ParseSpec("goarm.yaml", []byte(spec))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if app.Name != "billing" || app.Module != "github.com/acme/billing" {
+			t.Fatalf("unexpected module: %+v", app)
+		}
+		if app.Framework != domain.FrameworkTypeGin || app.DbType != domain.DBTypePostgres || app.Cache != domain.CacheTypeRedis {
+			t.Fatalf("unexpected app: %+v", app)
+		}
+		if app.DataAccess != domain.DataAccessGORM {
+			t.Fatalf("unexpected data access: %q", app.DataAccess)
+		}
+		if !app.HasFeature(domain.FeatureDocker) || app.HasFeature(domain.FeatureLinter) {
+			t.Fatalf("unexpected features: %v", app.Features)
+		}
+		if app.Port != "9090" || len(app.Envs) != 2 {
+			t.Fatalf("unexpected settings: %+v", app)
+		}
+	})
+
+	t.Run("omitted features use defaults", func(t *testing.T) {
+		app, err := ParseSpec("goarm.yaml", []byte("module: a\nframework: fiber\ndatabase: sqlite\n"))
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if got := app.WithDefaults().Features; len(got) != len(domain.DefaultFeatures) {
+			t.Fatalf("expected default features, got %v", got)
+		}
+	})
+
+	t.Run("errors carry line numbers", func(t *testing.T) {
+		spec := "module: a\nframework: rails\ndatabase: sqlite\nenvs:\n  - dev\n  - dev\n"
+		_, err := ParseSpec("goarm.yaml", []byte(spec))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		for _, want := range []string{"goarm.yaml:2: unsupported framework", "goarm.yaml:6: duplicate env"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("expected %q in %q", want, err)
+			}
+		}
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		_, err := ParseSpec("goarm.yaml", []byte("module: a\nframework: gin\ndatabase: mysql\nport: 1\n"))
+		if err == nil || !strings.Contains(err.Error(), `goarm.yaml:4: unknown field "port"`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("sqlc needs the raw data access", func(t *testing.T) {
+		_, err := ParseSpec("goarm.yaml", []byte("module: a\nframework: gin\ndatabase: mysql\norm: gorm\nfeatures: [sqlc]\n"))
+		if err == nil || !strings.Contains(err.Error(), "goarm.yaml:4: sqlc can't be used with gorm") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("sqlc needs an SQL database", func(t *testing.T) {
+		_, err := ParseSpec("goarm.yaml", []byte("module: a\nframework: gin\ndatabase: mongo\nfeatures: [sqlc]\n"))
+		if err == nil || !strings.Contains(err.Error(), "goarm.yaml:3: sqlc needs an SQL database") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("orm needs an SQL database", func(t *testing.T) {
+		_, err := ParseSpec("goarm.yaml", []byte("module: a\nframework: gin\ndatabase: mongo\norm: ent\n"))
+		if err == nil || !strings.Contains(err.Error(), "goarm.yaml:3: ent needs an SQL database") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("missing required values", func(t *testing.T) {
+		_, err := ParseSpec("goarm.yaml", []byte("features: []\n"))
+		if err == nil || !strings.Contains(err.Error(), "goarm.yaml: module is required") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -40,6 +40,24 @@ var (
 				Foreground(lipgloss.Color("10")).
 				Bold(true)
 
+	scfItemStyle = lipgloss.NewStyle().
+			PaddingLeft(2).
+			Foreground(lipgloss.Color("#00BFFF"))
+
+	scfSelectedItemStyle = lipgloss.NewStyle().
+				PaddingLeft(1).
+				Foreground(lipgloss.Color("10")).
+				Bold(true)
+
+	sofItemStyle = lipgloss.NewStyle().
+			PaddingLeft(2).
+			Foreground(lipgloss.Color("#00BFFF"))
+
+	sofSelectedItemStyle = lipgloss.NewStyle().
+				PaddingLeft(1).
+				Foreground(lipgloss.Color("10")).
+				Bold(true)
+
 	errorStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF6B6B")).
 			Bold(true).
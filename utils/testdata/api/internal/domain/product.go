@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+type Product struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Tags      []Tag     `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	secret    string
+}
+
+type Tag struct {
+	Label  string `json:"label"`
+	Parent *Tag   `json:"parent"`
+}
@@ -0,0 +1,7 @@
+package handler
+
+type jsonResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Data    any    `json:"data"`
+}
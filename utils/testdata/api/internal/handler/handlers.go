@@ -0,0 +1,66 @@
+package handler
+
+import "github.com/gin-gonic/gin"
+
+func (h *Handler) Pong(ctx *gin.Context) {
+	successResponse(ctx, "pong")
+}
+
+func (h *Handler) CreateProduct(ctx *gin.Context) {
+	var product domain.Product
+	if err := ctx.ShouldBindJSON(&product); err != nil {
+		errBadResponse(ctx, err)
+		return
+	}
+
+	product, err := h.service.CreateProduct(ctx, product)
+	if err != nil {
+		errInternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	successResponse(ctx, product)
+}
+
+func (h *Handler) ListProducts(ctx *gin.Context) {
+	products, err := h.service.ListProducts(ctx)
+	if err != nil {
+		errInternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	successResponse(ctx, products)
+}
+
+type user struct {
+	Name   string               `json:"name"`
+	Claims jwt.RegisteredClaims `json:"claims"`
+}
+
+func (h *Handler) Auth(ctx *gin.Context) {
+	ctx.Set("user", &user{Name: ctx.GetHeader("X-User")})
+	ctx.Next()
+}
+
+func (h *Handler) Me(ctx *gin.Context) {
+	successResponse(ctx, userFrom(ctx))
+}
+
+func (h *Handler) Token(ctx *gin.Context) {
+	token, err := h.sign(userFrom(ctx))
+	if err != nil {
+		errInternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	successResponse(ctx, token)
+}
+
+func (h *Handler) sign(u *user) (string, error) {
+	return u.Name, nil
+}
+
+func userFrom(ctx *gin.Context) *user {
+	u, _ := ctx.Value("user").(*user)
+	return u
+}
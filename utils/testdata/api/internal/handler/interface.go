@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"context"
+
+	"api/internal/domain"
+)
+
+type ServiceInterface interface {
+	CreateProduct(ctx context.Context, product domain.Product) (domain.Product, error)
+	ListProducts(ctx context.Context) ([]domain.Product, error)
+}
@@ -0,0 +1,13 @@
+package handler
+
+import "github.com/gin-gonic/gin"
+
+func BindRoutes(app *gin.Engine, h *Handler) {
+	app.GET("/ping", h.Pong)
+	api := app.Group("/api")
+	products := api.Group("/products")
+	products.POST("", h.CreateProduct)
+	products.GET("/", h.ListProducts)
+	app.GET("/me", h.Auth, h.Me)
+	app.GET("/me/token", h.Auth, h.Token)
+}
@@ -0,0 +1,5 @@
+package jwt
+
+import "github.com/golang-jwt/jwt/v5"
+
+type RegisteredClaims = jwt.RegisteredClaims